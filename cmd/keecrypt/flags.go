@@ -0,0 +1,204 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"hash"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/keecon/pkg-go/crypto/cipher"
+)
+
+var algorithms = map[string]cipher.Option{
+	"aes256":             cipher.WithAES256(),
+	"aes192":             cipher.WithAES192(),
+	"aes128":             cipher.WithAES128(),
+	"aes256-gcm-siv":     cipher.WithAES256GCMSIV(),
+	"aes128-gcm-siv":     cipher.WithAES128GCMSIV(),
+	"chacha20-poly1305":  cipher.WithChaCha20Poly1305(),
+	"xchacha20-poly1305": cipher.WithXChaCha20Poly1305(),
+}
+
+var hashes = map[string]func() hash.Hash{
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+// encodings of ciphertext, raw is binary
+var encodings = map[string]cipher.Encoding{
+	"base64":    cipher.StdBase64,
+	"base64url": cipher.RawURLBase64,
+	"hex":       cipher.Hex,
+	"raw":       nil,
+}
+
+// cipherFlags holds flags of cipher.AES options, prefix separates the new cipher of rekey
+type cipherFlags struct {
+	secret      string
+	alg         string
+	nonceLen    int
+	hkdfHash    string
+	hkdfInfo    string
+	keyID       uint64
+	envelope    bool
+	randomNonce bool
+	segmentSize int
+	argon2id    string
+	scrypt      string
+}
+
+func registerCipherFlags(fs *flag.FlagSet, prefix, secretEnv string) *cipherFlags {
+	f := &cipherFlags{}
+	fs.StringVar(&f.secret, prefix+"secret", "env:"+secretEnv, `secret source, "env:NAME" or "file:PATH"`)
+	fs.StringVar(&f.alg, prefix+"alg", "aes256", "algorithm: "+keys(algorithms))
+	fs.IntVar(&f.nonceLen, prefix+"nonce-len", 0, "nonce length in bytes, 0 is the algorithm default")
+	fs.StringVar(&f.hkdfHash, prefix+"hkdf-hash", "sha256", "HKDF hash: "+keys(hashes))
+	fs.StringVar(&f.hkdfInfo, prefix+"hkdf-info", "", "HKDF info")
+	fs.Uint64Var(&f.keyID, prefix+"key-id", 0, "envelope key id")
+	fs.BoolVar(&f.envelope, prefix+"envelope", false, "envelope format")
+	fs.BoolVar(&f.randomNonce, prefix+"random-nonce", false, "random nonce")
+	fs.IntVar(&f.segmentSize, prefix+"segment-size", 0, "stream segment size in bytes, 0 is the default")
+	fs.StringVar(&f.argon2id, prefix+"argon2id", "", `argon2id password stretching "time,memory,threads"`)
+	fs.StringVar(&f.scrypt, prefix+"scrypt", "", `scrypt password stretching "n,r,p"`)
+	return f
+}
+
+// options returns cipher.AES options of flags, the order follows the flags dependencies
+func (f *cipherFlags) options() ([]cipher.Option, error) {
+	alg, ok := algorithms[f.alg]
+	if !ok {
+		return nil, fmt.Errorf("unknown algorithm: %s", f.alg)
+	}
+	hkdfHash, ok := hashes[f.hkdfHash]
+	if !ok {
+		return nil, fmt.Errorf("unknown hkdf hash: %s", f.hkdfHash)
+	}
+	if 0xffffffff < f.keyID {
+		return nil, fmt.Errorf("invalid key id: %d", f.keyID)
+	}
+
+	opts := []cipher.Option{alg, cipher.WithHKDFHash(hkdfHash)}
+	if 0 < f.nonceLen {
+		opts = append(opts, cipher.WithNonceLength(f.nonceLen))
+	}
+	if f.hkdfInfo != "" {
+		opts = append(opts, cipher.WithHKDFInfo([]byte(f.hkdfInfo)))
+	}
+	if f.keyID != 0 {
+		opts = append(opts, cipher.WithKeyID(uint32(f.keyID)))
+	}
+	if f.envelope {
+		opts = append(opts, cipher.WithEnvelope())
+	}
+	if f.randomNonce {
+		opts = append(opts, cipher.WithRandomNonce())
+	}
+	if 0 < f.segmentSize {
+		opts = append(opts, cipher.WithSegmentSize(f.segmentSize))
+	}
+	if f.argon2id != "" {
+		p, err := parseParams(f.argon2id)
+		if err != nil || 0xff < p[2] {
+			return nil, fmt.Errorf("invalid argon2id params: %s", f.argon2id)
+		}
+		opts = append(opts, cipher.WithArgon2id(p[0], p[1], uint8(p[2])))
+	}
+	if f.scrypt != "" {
+		p, err := parseParams(f.scrypt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scrypt params: %s", f.scrypt)
+		}
+		opts = append(opts, cipher.WithScrypt(p[0], p[1], p[2]))
+	}
+	return opts, nil
+}
+
+// cipher returns cipher.AES of flags, the caller closes it
+func (f *cipherFlags) cipher() (*cipher.AES, error) {
+	opts, err := f.options()
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := cipher.LoadSecret(f.secret)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewAESFromSecret(secret, opts...), nil
+}
+
+func parseParams(s string) ([3]uint32, error) {
+	var p [3]uint32
+	parts := strings.Split(s, ",")
+	if len(parts) != len(p) {
+		return p, fmt.Errorf("want %d params: %s", len(p), s)
+	}
+
+	for i, part := range parts {
+		v, err := strconv.ParseUint(strings.TrimSpace(part), 10, 32)
+		if err != nil {
+			return p, err
+		}
+		p[i] = uint32(v)
+	}
+	return p, nil
+}
+
+// saltFlags holds flags of the salt
+type saltFlags struct {
+	kind  string
+	value string
+}
+
+func registerSaltFlags(fs *flag.FlagSet) *saltFlags {
+	f := &saltFlags{}
+	fs.StringVar(&f.kind, "salt-type", "int64", "salt type: int64, int32, hex, string")
+	fs.StringVar(&f.value, "salt", "", "salt value (e.g. a row id)")
+	return f
+}
+
+// salt returns salt bytes as the services build them with NewInt64Salt and NewInt32Salt
+func (f *saltFlags) salt(c *cipher.AES) ([]byte, error) {
+	switch f.kind {
+	case "int64":
+		v, err := strconv.ParseInt(f.value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid int64 salt: %w", err)
+		}
+		return c.NewInt64Salt(v), nil
+	case "int32":
+		v, err := strconv.ParseInt(f.value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid int32 salt: %w", err)
+		}
+		return c.NewInt32Salt(int32(v)), nil
+	case "hex":
+		b, err := hex.DecodeString(f.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex salt: %w", err)
+		}
+		return b, nil
+	case "string":
+		return []byte(f.value), nil
+	}
+	return nil, fmt.Errorf("unknown salt type: %s", f.kind)
+}
+
+func keys[V any](m map[string]V) string {
+	var ret []string
+	for k := range m {
+		ret = append(ret, k)
+	}
+	slices.Sort(ret)
+	return strings.Join(ret, ", ")
+}
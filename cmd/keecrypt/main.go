@@ -0,0 +1,328 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command keecrypt encrypts, decrypts and re-encrypts values with cipher.AES settings
+// of the services, e.g. to decrypt a database value or encrypt a config value.
+//
+// Usage:
+//
+//	keecrypt encrypt [flags]
+//	keecrypt decrypt [flags]
+//	keecrypt rekey [flags]
+//
+// The secret is read from KEECRYPT_SECRET by default, the new secret of rekey
+// from KEECRYPT_TO_SECRET. Input is stdin and output is stdout unless -in and
+// -out name different files, the output file is replaced only on success.
+// Ciphertext is base64 text unless -encoding is set, -stream encrypts large
+// files in segments and reads and writes binary.
+//
+// Example:
+//
+//	printf 'user@example.com' | keecrypt encrypt -salt 42
+//	keecrypt decrypt -salt 42 -alg chacha20-poly1305 -encoding hex -in value.txt
+//	keecrypt rekey -salt 42 -to-secret file:/run/secrets/new -to-hkdf-info v2
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/keecon/pkg-go/crypto/cipher"
+	"github.com/keecon/pkg-go/crypto/rekey"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "keecrypt:", err)
+		}
+		os.Exit(2)
+	}
+}
+
+const usage = `usage: keecrypt <encrypt|decrypt|rekey> [flags]
+
+Run "keecrypt <command> -h" for the flags of the command.
+`
+
+// command holds flags of every command
+type command struct {
+	name     string
+	from     *cipherFlags
+	to       *cipherFlags
+	salt     *saltFlags
+	in       string
+	out      string
+	encoding string
+	aad      string
+	stream   bool
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return flag.ErrHelp
+	}
+
+	cmd := &command{name: args[0]}
+	fs := flag.NewFlagSet("keecrypt "+cmd.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	switch cmd.name {
+	case "encrypt", "decrypt":
+		cmd.from = registerCipherFlags(fs, "", "KEECRYPT_SECRET")
+	case "rekey":
+		cmd.from = registerCipherFlags(fs, "", "KEECRYPT_SECRET")
+		cmd.to = registerCipherFlags(fs, "to-", "KEECRYPT_TO_SECRET")
+	default:
+		fmt.Fprint(stderr, usage)
+		return fmt.Errorf("unknown command: %s", cmd.name)
+	}
+	cmd.salt = registerSaltFlags(fs)
+	fs.StringVar(&cmd.in, "in", "-", `input file, "-" is stdin`)
+	fs.StringVar(&cmd.out, "out", "-", `output file, "-" is stdout`)
+	fs.StringVar(&cmd.encoding, "encoding", "base64", "ciphertext encoding: "+keys(encodings))
+	fs.StringVar(&cmd.aad, "aad", "", "additional authenticated data")
+	fs.BoolVar(&cmd.stream, "stream", false, "segmented stream format of large files")
+
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	return cmd.run(stdin, stdout)
+}
+
+func (cmd *command) run(stdin io.Reader, stdout io.Writer) (err error) {
+	encoding, ok := encodings[cmd.encoding]
+	if !ok {
+		return fmt.Errorf("unknown encoding: %s", cmd.encoding)
+	}
+	if cmd.stream && cmd.aad != "" {
+		return errors.New("-aad is not supported with -stream")
+	}
+	if err := checkInOut(cmd.in, cmd.out); err != nil {
+		return err
+	}
+
+	from, err := cmd.from.cipher()
+	if err != nil {
+		return err
+	}
+	defer from.Close()
+
+	salt, err := cmd.salt.salt(from)
+	if err != nil {
+		return err
+	}
+
+	r, err := openInput(cmd.in, stdin)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	// the input is read before the output is created, unless it is streamed
+	var input []byte
+	if !cmd.stream {
+		if input, err = io.ReadAll(r); err != nil {
+			return fmt.Errorf("read input: %w", err)
+		}
+	}
+
+	w, err := createOutput(cmd.out, stdout)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			w.abort()
+			return
+		}
+		err = w.commit()
+	}()
+
+	switch {
+	case cmd.name == "rekey":
+		to, err := cmd.to.cipher()
+		if err != nil {
+			return err
+		}
+		defer to.Close()
+		return cmd.rekey(rekey.New(from, to), salt, input, r, w, encoding)
+	case cmd.stream:
+		return cmd.runStream(from, salt, r, w)
+	}
+
+	var output []byte
+	if cmd.name == "encrypt" {
+		if output, err = from.EncryptWithAAD(input, salt, []byte(cmd.aad)); err != nil {
+			return err
+		}
+		return writeEncoded(w, output, encoding)
+	}
+
+	if input, err = readEncoded(input, encoding); err != nil {
+		return err
+	}
+	if output, err = from.DecryptWithAAD(input, salt, []byte(cmd.aad)); err != nil {
+		return err
+	}
+	_, err = w.Write(output)
+	return err
+}
+
+func (cmd *command) runStream(c *cipher.AES, salt []byte, r io.Reader, w io.Writer) error {
+	if cmd.name == "encrypt" {
+		ew, err := c.NewEncryptWriter(w, salt)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(ew, r); err != nil {
+			return err
+		}
+		return ew.Close()
+	}
+
+	dr, err := c.NewDecryptReader(r, salt)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, dr)
+	return err
+}
+
+func (cmd *command) rekey(rk *rekey.Rekeyer, salt, input []byte, r io.Reader, w io.Writer, encoding cipher.Encoding) error {
+	if cmd.stream {
+		return rk.Stream(w, r, salt)
+	}
+
+	input, err := readEncoded(input, encoding)
+	if err != nil {
+		return err
+	}
+
+	output, err := rk.Bytes(input, salt, []byte(cmd.aad))
+	if err != nil {
+		return err
+	}
+	return writeEncoded(w, output, encoding)
+}
+
+// readEncoded decodes text input, surrounding white spaces (e.g. a trailing newline) are ignored
+func readEncoded(input []byte, encoding cipher.Encoding) ([]byte, error) {
+	if encoding == nil {
+		return input, nil
+	}
+
+	b, err := encoding.DecodeString(string(bytes.TrimSpace(input)))
+	if err != nil {
+		return nil, fmt.Errorf("decode input: %w", err)
+	}
+	return b, nil
+}
+
+// writeEncoded writes encoded output followed by a newline
+func writeEncoded(w io.Writer, output []byte, encoding cipher.Encoding) error {
+	if encoding == nil {
+		_, err := w.Write(output)
+		return err
+	}
+
+	_, err := io.WriteString(w, encoding.EncodeToString(output)+"\n")
+	return err
+}
+
+func openInput(name string, stdin io.Reader) (io.ReadCloser, error) {
+	if name == "-" {
+		return io.NopCloser(stdin), nil
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("open input: %w", err)
+	}
+	return f, nil
+}
+
+// checkInOut refuses the input file to be the output file, it would be overwritten while read
+func checkInOut(in, out string) error {
+	if in == "-" || out == "-" {
+		return nil
+	}
+
+	inAbs, err := filepath.Abs(in)
+	if err != nil {
+		return fmt.Errorf("input path: %w", err)
+	}
+	outAbs, err := filepath.Abs(out)
+	if err != nil {
+		return fmt.Errorf("output path: %w", err)
+	}
+
+	same := inAbs == outAbs
+	if inInfo, err := os.Stat(in); err == nil {
+		if outInfo, err := os.Stat(out); err == nil {
+			same = same || os.SameFile(inInfo, outInfo)
+		}
+	}
+	if same {
+		return errors.New("-in and -out must be different files")
+	}
+	return nil
+}
+
+// output writes to stdout, or to a temp file renamed to the output file on commit
+type output struct {
+	io.Writer
+	file *os.File
+	name string
+}
+
+func createOutput(name string, stdout io.Writer) (*output, error) {
+	if name == "-" {
+		return &output{Writer: stdout}, nil
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*")
+	if err != nil {
+		return nil, fmt.Errorf("create output: %w", err)
+	}
+	return &output{Writer: f, file: f, name: name}, nil
+}
+
+func (o *output) commit() error {
+	if o.file == nil {
+		return nil
+	}
+
+	if err := o.file.Sync(); err != nil {
+		o.abort()
+		return fmt.Errorf("sync output: %w", err)
+	}
+	if err := o.file.Close(); err != nil {
+		_ = os.Remove(o.file.Name())
+		return fmt.Errorf("close output: %w", err)
+	}
+	if err := os.Rename(o.file.Name(), o.name); err != nil {
+		_ = os.Remove(o.file.Name())
+		return fmt.Errorf("rename output: %w", err)
+	}
+	return nil
+}
+
+func (o *output) abort() {
+	if o.file == nil {
+		return
+	}
+
+	_ = o.file.Close()
+	_ = os.Remove(o.file.Name())
+}
@@ -0,0 +1,225 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/keecon/pkg-go/crypto/cipher"
+	"github.com/stretchr/testify/assert"
+)
+
+func execute(t *testing.T, stdin []byte, args ...string) ([]byte, error) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	err := run(args, bytes.NewReader(stdin), &stdout, &stderr)
+	return stdout.Bytes(), err
+}
+
+func TestRunEncryptDecrypt(t *testing.T) {
+	// given
+	t.Setenv("KEECRYPT_SECRET", "secret")
+
+	// dataset
+	dataset := []struct {
+		name string
+		args []string
+	}{
+		{
+			name: "Default",
+			args: []string{"-salt", "42"},
+		},
+		{
+			name: "Int32SaltHexEncoding",
+			args: []string{"-salt-type", "int32", "-salt", "42", "-encoding", "hex"},
+		},
+		{
+			name: "HexSaltRawEncoding",
+			args: []string{"-salt-type", "hex", "-salt", "0a0b0c", "-encoding", "raw"},
+		},
+		{
+			name: "ChaCha20HKDF",
+			args: []string{"-salt", "42", "-alg", "chacha20-poly1305", "-hkdf-hash", "sha512", "-hkdf-info", "v2"},
+		},
+		{
+			name: "GCMNonceLength",
+			args: []string{"-salt", "42", "-alg", "aes128", "-nonce-len", "16", "-encoding", "base64url"},
+		},
+		{
+			name: "Envelope",
+			args: []string{"-salt", "42", "-envelope", "-key-id", "7", "-random-nonce", "-aad", "users.email"},
+		},
+		{
+			name: "Scrypt",
+			args: []string{"-salt", "42", "-scrypt", "1024,8,1"},
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			plaintext := []byte("user@example.com")
+
+			// when
+			ciphertext, err := execute(t, plaintext, append([]string{"encrypt"}, v.args...)...)
+			assert.NoError(t, err)
+			got, err := execute(t, ciphertext, append([]string{"decrypt"}, v.args...)...)
+
+			// then
+			assert.NoError(t, err)
+			assert.Equal(t, plaintext, got)
+		})
+	}
+}
+
+func TestRunCompatibleWithCipher(t *testing.T) {
+	// given
+	t.Setenv("KEECRYPT_SECRET", "secret")
+	c := cipher.NewAES("secret", cipher.WithAES128(), cipher.WithHKDFInfo([]byte("v1")))
+	ciphertext, err := c.Encrypt([]byte("hello"), c.NewInt64Salt(42))
+	assert.NoError(t, err)
+
+	// when
+	got, err := execute(t, []byte(base64.StdEncoding.EncodeToString(ciphertext)+"\n"),
+		"decrypt", "-salt", "42", "-alg", "aes128", "-hkdf-info", "v1")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(got))
+}
+
+func TestRunFile(t *testing.T) {
+	// given
+	t.Setenv("KEECRYPT_SECRET", "secret")
+	dir := t.TempDir()
+	plain, enc, dec := filepath.Join(dir, "plain"), filepath.Join(dir, "enc"), filepath.Join(dir, "dec")
+	plaintext := bytes.Repeat([]byte("hello world "), 10000)
+	assert.NoError(t, os.WriteFile(plain, plaintext, 0o600))
+
+	// when
+	_, err := execute(t, nil, "encrypt", "-stream", "-segment-size", "4096", "-salt", "42", "-in", plain, "-out", enc)
+	assert.NoError(t, err)
+	_, err = execute(t, nil, "decrypt", "-stream", "-salt", "42", "-in", enc, "-out", dec)
+	assert.NoError(t, err)
+	got, err := os.ReadFile(dec)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, plaintext, got)
+}
+
+func TestRunFileOutput(t *testing.T) {
+	// given
+	t.Setenv("KEECRYPT_SECRET", "secret")
+	dir := t.TempDir()
+	plain, enc := filepath.Join(dir, "plain"), filepath.Join(dir, "enc")
+	assert.NoError(t, os.WriteFile(plain, []byte("hello"), 0o600))
+	assert.NoError(t, os.WriteFile(enc, []byte("previous"), 0o600))
+
+	// when
+	_, errInPlace := execute(t, nil, "encrypt", "-salt", "42", "-in", plain, "-out", filepath.Join(dir, ".", "plain"))
+	_, errDecrypt := execute(t, nil, "decrypt", "-salt", "42", "-in", plain, "-out", enc)
+	gotPlain, _ := os.ReadFile(plain)
+	gotEnc, _ := os.ReadFile(enc)
+	entries, _ := os.ReadDir(dir)
+
+	// then
+	assert.ErrorContains(t, errInPlace, "must be different files")
+	assert.Error(t, errDecrypt)
+	assert.Equal(t, "hello", string(gotPlain))
+	assert.Equal(t, "previous", string(gotEnc))
+	assert.Len(t, entries, 2)
+}
+
+func TestRunRekey(t *testing.T) {
+	// given
+	t.Setenv("KEECRYPT_SECRET", "old-secret")
+	secretFile := filepath.Join(t.TempDir(), "new")
+	assert.NoError(t, os.WriteFile(secretFile, []byte("new-secret\n"), 0o600))
+	ciphertext, err := execute(t, []byte("hello"), "encrypt", "-salt", "42")
+	assert.NoError(t, err)
+
+	// when
+	rekeyed, err := execute(t, ciphertext, "rekey", "-salt", "42",
+		"-to-secret", "file:"+secretFile, "-to-alg", "aes256-gcm-siv", "-to-envelope")
+	assert.NoError(t, err)
+	got, err := execute(t, rekeyed, "decrypt", "-salt", "42",
+		"-secret", "file:"+secretFile, "-alg", "aes256-gcm-siv", "-envelope")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(got))
+}
+
+func TestRunErrors(t *testing.T) {
+	// given
+	t.Setenv("KEECRYPT_SECRET", "secret")
+
+	// dataset
+	dataset := []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{
+			name: "UnknownCommand",
+			args: []string{"sign"},
+			err:  "unknown command",
+		},
+		{
+			name: "UnknownAlgorithm",
+			args: []string{"encrypt", "-salt", "42", "-alg", "des"},
+			err:  "unknown algorithm",
+		},
+		{
+			name: "InvalidSalt",
+			args: []string{"encrypt", "-salt", "abc"},
+			err:  "invalid int64 salt",
+		},
+		{
+			name: "MissingSecret",
+			args: []string{"encrypt", "-salt", "42", "-secret", "env:KEECRYPT_MISSING"},
+			err:  "secret not found",
+		},
+		{
+			name: "InvalidScrypt",
+			args: []string{"encrypt", "-salt", "42", "-scrypt", "1024"},
+			err:  "invalid scrypt params",
+		},
+		{
+			name: "WrongSalt",
+			args: []string{"decrypt", "-salt", "43"},
+			err:  "authentication failed",
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			ciphertext, err := execute(t, []byte("hello"), "encrypt", "-salt", "42")
+			assert.NoError(t, err)
+
+			// when
+			_, err = execute(t, ciphertext, v.args...)
+
+			// then
+			assert.Error(t, err)
+			assert.True(t, strings.Contains(err.Error(), v.err), err.Error())
+		})
+	}
+
+	var stderr bytes.Buffer
+	assert.Error(t, run(nil, nil, io.Discard, &stderr))
+	assert.Contains(t, stderr.String(), "usage")
+}
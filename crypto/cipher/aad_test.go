@@ -0,0 +1,59 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAAD(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name string
+		opts []Option
+	}{
+		{
+			name: "Default",
+		},
+		{
+			name: "RandomNonce",
+			opts: []Option{WithRandomNonce()},
+		},
+		{
+			name: "Envelope",
+			opts: []Option{WithEnvelope(), WithRandomNonce()},
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			secret := newRandHex(t, 16)
+			plaintext := newRandBytes(t, 128)
+			c := NewAES(secret, v.opts...)
+			salt := c.NewInt64Salt(42)
+			aadA := []byte("users.email.1")
+			aadB := []byte("users.email.2")
+
+			// when
+			ciphertext, err := c.EncryptWithAAD(plaintext, salt, aadA)
+			assert.NoError(t, err)
+
+			rettext, err := c.DecryptWithAAD(ciphertext, salt, aadA)
+			assert.NoError(t, err)
+
+			_, errOther := c.DecryptWithAAD(ciphertext, salt, aadB)
+			_, errNone := c.Decrypt(ciphertext, salt)
+
+			// then
+			assert.Equal(t, plaintext, rettext)
+			assert.Error(t, errOther)
+			assert.Error(t, errNone)
+		})
+	}
+}
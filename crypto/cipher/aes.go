@@ -2,28 +2,41 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-// Package cipher implements AES encryption with GCM, KDF Hash
+// Package cipher implements AES encryption with GCM, GCM-SIV, ChaCha20-Poly1305, KDF Hash
 package cipher
 
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"hash"
+	"io"
+	"slices"
 
+	"golang.org/x/crypto/chacha20poly1305"
 	"golang.org/x/crypto/hkdf"
 )
 
 // AES implements encrypt/decrypt AES algorithm (GCM)
 type AES struct {
-	secret    string
-	alg       string
-	algKeyLen int
-	nonceLen  int
-	hkdfHash  func() hash.Hash
-	hkdfInfo  []byte
+	secret      *Secret
+	alg         Algorithm
+	nonceLen    int
+	hkdfHash    func() hash.Hash
+	hkdfInfo    []byte
+	keyID       uint32
+	envelope    bool
+	randomNonce bool
+	segmentSize int
+	encoding    Encoding
+
+	password      *passwordKDF
+	passwordCache passwordCache
+	cache         *aeadCache
 }
 
 // Option defines configure AES settings
@@ -31,12 +44,18 @@ type Option func(*AES)
 
 // NewAES creates AES
 func NewAES(secret string, opts ...Option) *AES {
+	return NewAESFromSecret(NewSecret([]byte(secret)), opts...)
+}
+
+// NewAESFromSecret creates AES with Secret, AES.Close closes the secret
+func NewAESFromSecret(secret *Secret, opts ...Option) *AES {
 	ret := &AES{
-		secret:    secret,
-		alg:       "AES256",   // recommends
-		algKeyLen: 32,         // recommends
-		nonceLen:  12,         // strongly recommends
-		hkdfHash:  sha256.New, // recommends
+		secret:      secret,
+		alg:         AlgorithmAES256, // recommends
+		nonceLen:    12,              // strongly recommends
+		hkdfHash:    sha256.New,      // recommends
+		segmentSize: defaultSegmentSize,
+		encoding:    StdBase64,
 	}
 
 	for _, o := range opts {
@@ -45,27 +64,76 @@ func NewAES(secret string, opts ...Option) *AES {
 	return ret
 }
 
+// Close zeroes the secret and drops cached keys, AES must not be used after Close
+func (c *AES) Close() error {
+	if c.cache != nil {
+		c.cache.purge()
+	}
+	if c.password != nil {
+		clear(c.password.key)
+	}
+	c.passwordCache.purge()
+	return c.secret.Close()
+}
+
 // WithAES256 configures AES256 algorithm
 func WithAES256() Option {
 	return func(c *AES) {
-		c.alg = "AES256"
-		c.algKeyLen = 32
+		c.alg = AlgorithmAES256
 	}
 }
 
 // WithAES192 configures AES192 algorithm
 func WithAES192() Option {
 	return func(c *AES) {
-		c.alg = "AES192"
-		c.algKeyLen = 24
+		c.alg = AlgorithmAES192
 	}
 }
 
 // WithAES128 configures AES128 algorithm
 func WithAES128() Option {
 	return func(c *AES) {
-		c.alg = "AES128"
-		c.algKeyLen = 16
+		c.alg = AlgorithmAES128
+	}
+}
+
+// WithAES256GCMSIV configures AES256 algorithm with nonce-misuse-resistant GCM-SIV (RFC 8452).
+// If a salt is reused, it only reveals whether two plaintexts are equal.
+// The nonce length is fixed to 12 bytes.
+func WithAES256GCMSIV() Option {
+	return func(c *AES) {
+		c.alg = AlgorithmAES256GCMSIV
+		c.nonceLen = gcmSIVNonceSize
+	}
+}
+
+// WithAES128GCMSIV configures AES128 algorithm with nonce-misuse-resistant GCM-SIV (RFC 8452).
+// If a salt is reused, it only reveals whether two plaintexts are equal.
+// The nonce length is fixed to 12 bytes.
+func WithAES128GCMSIV() Option {
+	return func(c *AES) {
+		c.alg = AlgorithmAES128GCMSIV
+		c.nonceLen = gcmSIVNonceSize
+	}
+}
+
+// WithChaCha20Poly1305 configures ChaCha20-Poly1305 algorithm,
+// it is faster than AES on hardware without AES instructions.
+// The nonce length is fixed to 12 bytes.
+func WithChaCha20Poly1305() Option {
+	return func(c *AES) {
+		c.alg = AlgorithmChaCha20Poly1305
+		c.nonceLen = chacha20poly1305.NonceSize
+	}
+}
+
+// WithXChaCha20Poly1305 configures XChaCha20-Poly1305 algorithm,
+// its 24 bytes nonce is large enough to be drawn at random safely.
+// The nonce length is fixed to 24 bytes.
+func WithXChaCha20Poly1305() Option {
+	return func(c *AES) {
+		c.alg = AlgorithmXChaCha20Poly1305
+		c.nonceLen = chacha20poly1305.NonceSizeX
 	}
 }
 
@@ -90,14 +158,50 @@ func WithHKDFInfo(info []byte) Option {
 	}
 }
 
-// NewInt64Salt returns bytes for using salt
+// WithKeyID configures key id written to the envelope header
+func WithKeyID(id uint32) Option {
+	return func(c *AES) {
+		c.keyID = id
+	}
+}
+
+// WithEnvelope configures self-describing envelope format.
+// Encrypt prepends a header that records the settings used,
+// and Decrypt reads them from the header instead of the options.
+func WithEnvelope() Option {
+	return func(c *AES) {
+		c.envelope = true
+	}
+}
+
+// WithRandomNonce configures random nonce mode.
+// Encrypt draws a fresh nonce from crypto/rand and prepends it to the ciphertext,
+// so the same salt can be reused for many plaintexts.
+// The default derives the nonce from the salt, then a salt must never be
+// reused for a different plaintext.
+func WithRandomNonce() Option {
+	return func(c *AES) {
+		c.randomNonce = true
+	}
+}
+
+// ReusesNonce reports whether a repeated salt reuses the nonce of a different plaintext,
+// it is false in random nonce mode and with nonce misuse resistant GCM-SIV
+func (c *AES) ReusesNonce() bool {
+	return !c.randomNonce && c.alg != AlgorithmAES128GCMSIV && c.alg != AlgorithmAES256GCMSIV
+}
+
+// NewInt64Salt returns bytes for using salt, it is 8 bytes little endian of data.
+// Use NewSaltBuilder to combine several components.
 func (c *AES) NewInt64Salt(data int64) []byte {
 	salt := make([]byte, 8)
 	binary.LittleEndian.PutUint64(salt, uint64(data))
 	return salt
 }
 
-// NewInt32Salt returns bytes for using salt
+// NewInt32Salt returns bytes for using salt, it is 8 bytes: 4 bytes little endian
+// of data followed by 4 zero bytes. It equals NewInt64Salt of non-negative data,
+// but not of negative data. The layout is kept for the existing ciphertexts.
 func (c *AES) NewInt32Salt(data int32) []byte {
 	salt := make([]byte, 8)
 	binary.LittleEndian.PutUint32(salt, uint32(data))
@@ -106,57 +210,217 @@ func (c *AES) NewInt32Salt(data int32) []byte {
 
 // Encrypt implements encrypt and authenticates plaintext
 func (c *AES) Encrypt(plaintext, salt []byte) ([]byte, error) {
-	key, nonce, err := c.newKeyNonce(salt)
+	return c.EncryptWithAAD(plaintext, salt, nil)
+}
+
+// Decrypt implements decrypt and authenticates ciphertext
+func (c *AES) Decrypt(ciphertext, salt []byte) ([]byte, error) {
+	return c.DecryptWithAAD(ciphertext, salt, nil)
+}
+
+// EncryptWithAAD implements encrypt and authenticates plaintext and additional data.
+// The additional data is not encrypted nor stored, it binds the ciphertext to
+// a context (e.g. table, column and primary key), which must be given to decrypt.
+func (c *AES) EncryptWithAAD(plaintext, salt, aad []byte) ([]byte, error) {
+	if c.envelope {
+		return c.sealEnvelope(plaintext, salt, aad)
+	}
+
+	p, err := c.params()
 	if err != nil {
 		return nil, err
 	}
+	return c.seal(nil, p, plaintext, salt, aad)
+}
+
+// DecryptWithAAD implements decrypt and authenticates ciphertext and additional data.
+// It fails if aad differs from the one given to encrypt.
+func (c *AES) DecryptWithAAD(ciphertext, salt, aad []byte) ([]byte, error) {
+	if c.envelope {
+		return c.openEnvelope(ciphertext, salt, aad)
+	}
 
-	aead, err := c.newCipherAEAD(key)
+	p, err := c.params()
 	if err != nil {
 		return nil, err
 	}
+	return c.open(p, ciphertext, salt, aad)
+}
 
-	return aead.Seal(nil, nonce, plaintext, nil), nil
+// aeadParams are settings to seal or open a message
+type aeadParams struct {
+	ikm         []byte
+	alg         Algorithm
+	nonceLen    int
+	randomNonce bool
 }
 
-// Decrypt implements decrypt and authenticates ciphertext
-func (c *AES) Decrypt(ciphertext, salt []byte) ([]byte, error) {
-	key, nonce, err := c.newKeyNonce(salt)
+func (c *AES) params() (aeadParams, error) {
+	ikm, err := c.secret.Bytes()
+	if err != nil {
+		return aeadParams{}, err
+	}
+
+	return aeadParams{
+		ikm:         ikm,
+		alg:         c.alg,
+		nonceLen:    c.nonceLen,
+		randomNonce: c.randomNonce,
+	}, nil
+}
+
+func (c *AES) seal(dst []byte, p aeadParams, plaintext, salt, ad []byte) ([]byte, error) {
+	aead, nonce, err := c.newAEAD(p, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.randomNonce {
+		if nonce, err = newRandomNonce(p.nonceLen); err != nil {
+			return nil, err
+		}
+		dst = append(dst, nonce...)
+	}
+	return aead.Seal(dst, nonce, plaintext, ad), nil
+}
+
+func (c *AES) open(p aeadParams, ciphertext, salt, ad []byte) ([]byte, error) {
+	aead, nonce, err := c.newAEAD(p, salt)
 	if err != nil {
 		return nil, err
 	}
 
-	aead, err := c.newCipherAEAD(key)
+	if p.randomNonce {
+		if len(ciphertext) < p.nonceLen {
+			return nil, errors.New("ciphertext too short")
+		}
+		nonce, ciphertext = ciphertext[:p.nonceLen], ciphertext[p.nonceLen:]
+	}
+	return aead.Open(nil, nonce, ciphertext, ad)
+}
+
+// newAEAD returns AEAD and derived nonce, nonce is nil in random nonce mode
+func (c *AES) newAEAD(p aeadParams, salt []byte) (cipher.AEAD, []byte, error) {
+	var cacheKey string
+	if c.cache != nil {
+		cacheKey = p.cacheKey(salt)
+		if aead, nonce, ok := c.cache.get(cacheKey); ok {
+			return aead, nonce, nil
+		}
+	}
+
+	var (
+		key, nonce []byte
+		err        error
+	)
+	if p.randomNonce {
+		key, err = c.newKey(p, salt)
+	} else {
+		key, nonce, err = c.newKeyNonce(p, salt)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	aead, err := newCipherAEAD(p.alg, key, p.nonceLen)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if c.cache != nil {
+		c.cache.add(cacheKey, aead, nonce)
+	}
+	return aead, nonce, nil
+}
+
+func (c *AES) newKey(p aeadParams, salt []byte) ([]byte, error) {
+	kdf := hkdf.New(c.hkdfHash, p.ikm, salt, c.hkdfInfo)
+	key := make([]byte, p.alg.KeyLen())
+	if _, err := kdf.Read(key); err != nil {
+		return nil, fmt.Errorf("hkdf expand key: %w", err)
+	}
+	return key, nil
+}
+
+// deriveKey derives n bytes key for label with HKDF info, it separates keys of the other features
+func (c *AES) deriveKey(label []byte, n int) ([]byte, error) {
+	if c.password != nil {
+		return nil, ErrPasswordKDFUnsupported
+	}
+
+	ikm, err := c.secret.Bytes()
 	if err != nil {
 		return nil, err
 	}
 
-	return aead.Open(nil, nonce, ciphertext, nil)
+	kdf := hkdf.New(c.hkdfHash, ikm, nil, append(slices.Clone(c.hkdfInfo), label...))
+	key := make([]byte, n)
+	if _, err := kdf.Read(key); err != nil {
+		return nil, fmt.Errorf("hkdf expand key: %w", err)
+	}
+	return key, nil
 }
 
-func (c *AES) newKeyNonce(salt []byte) (key []byte, nonce []byte, err error) {
-	kdf := hkdf.New(c.hkdfHash, []byte(c.secret), salt, c.hkdfInfo)
-	key = make([]byte, c.algKeyLen)
+func (c *AES) newKeyNonce(p aeadParams, salt []byte) (key []byte, nonce []byte, err error) {
+	kdf := hkdf.New(c.hkdfHash, p.ikm, salt, c.hkdfInfo)
+	key = make([]byte, p.alg.KeyLen())
 	if _, err := kdf.Read(key); err != nil {
 		return nil, nil, fmt.Errorf("hkdf expand key: %w", err)
 	}
 
-	nonce = make([]byte, c.nonceLen)
+	nonce = make([]byte, p.nonceLen)
 	if _, err := kdf.Read(nonce); err != nil {
 		return nil, nil, fmt.Errorf("hkdf expand nonce: %w", err)
 	}
 	return key, nonce, nil
 }
 
-func (c *AES) newCipherAEAD(key []byte) (cipher.AEAD, error) {
+func newRandomNonce(nonceLen int) ([]byte, error) {
+	nonce := make([]byte, nonceLen)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("read random nonce: %w", err)
+	}
+	return nonce, nil
+}
+
+func newCipherAEAD(alg Algorithm, key []byte, nonceLen int) (cipher.AEAD, error) {
+	switch alg {
+	case AlgorithmChaCha20Poly1305, AlgorithmXChaCha20Poly1305:
+		return newChaChaAEAD(alg, key, nonceLen)
+	case AlgorithmAES128GCMSIV, AlgorithmAES256GCMSIV:
+		if nonceLen != gcmSIVNonceSize {
+			return nil, fmt.Errorf("invalid %s nonce length: %d", alg, nonceLen)
+		}
+		return newGCMSIV(key)
+	}
+	if alg.KeyLen() == 0 {
+		return nil, fmt.Errorf("unknown algorithm: %s", alg)
+	}
+
 	block, err := aes.NewCipher(key)
 	if err != nil {
 		return nil, fmt.Errorf("new aes cipher: %w", err)
 	}
 
-	aead, err := cipher.NewGCMWithNonceSize(block, c.nonceLen)
+	aead, err := cipher.NewGCMWithNonceSize(block, nonceLen)
 	if err != nil {
 		return nil, fmt.Errorf("new aead gcm: %w", err)
 	}
 	return aead, nil
 }
+
+func newChaChaAEAD(alg Algorithm, key []byte, nonceLen int) (aead cipher.AEAD, err error) {
+	if alg == AlgorithmXChaCha20Poly1305 {
+		aead, err = chacha20poly1305.NewX(key)
+	} else {
+		aead, err = chacha20poly1305.New(key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("new aead %s: %w", alg, err)
+	}
+
+	if aead.NonceSize() != nonceLen {
+		return nil, fmt.Errorf("invalid %s nonce length: %d", alg, nonceLen)
+	}
+	return aead, nil
+}
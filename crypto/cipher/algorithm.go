@@ -0,0 +1,55 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import "strconv"
+
+// Algorithm identifies AEAD algorithm, it is stored in the envelope header
+type Algorithm byte
+
+// Algorithm ids, do not reorder: the values are persisted
+const (
+	AlgorithmAES128 Algorithm = iota + 1
+	AlgorithmAES192
+	AlgorithmAES256
+	AlgorithmChaCha20Poly1305
+	AlgorithmXChaCha20Poly1305
+	AlgorithmAES128GCMSIV
+	AlgorithmAES256GCMSIV
+)
+
+// KeyLen returns key length in bytes, 0 if algorithm is unknown
+func (a Algorithm) KeyLen() int {
+	switch a {
+	case AlgorithmAES128, AlgorithmAES128GCMSIV:
+		return 16
+	case AlgorithmAES192:
+		return 24
+	case AlgorithmAES256, AlgorithmChaCha20Poly1305, AlgorithmXChaCha20Poly1305, AlgorithmAES256GCMSIV:
+		return 32
+	}
+	return 0
+}
+
+// String returns algorithm name
+func (a Algorithm) String() string {
+	switch a {
+	case AlgorithmAES128:
+		return "AES128"
+	case AlgorithmAES192:
+		return "AES192"
+	case AlgorithmAES256:
+		return "AES256"
+	case AlgorithmChaCha20Poly1305:
+		return "ChaCha20Poly1305"
+	case AlgorithmXChaCha20Poly1305:
+		return "XChaCha20Poly1305"
+	case AlgorithmAES128GCMSIV:
+		return "AES128GCMSIV"
+	case AlgorithmAES256GCMSIV:
+		return "AES256GCMSIV"
+	}
+	return "Algorithm(" + strconv.Itoa(int(a)) + ")"
+}
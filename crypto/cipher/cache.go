@@ -0,0 +1,107 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"container/list"
+	"crypto/cipher"
+	"crypto/sha256"
+	"sync"
+)
+
+// WithAEADCache configures bounded LRU cache of derived AEADs keyed by salt.
+// It skips HKDF and cipher setup when many messages share a salt,
+// at the cost of keeping up to size derived keys in memory.
+func WithAEADCache(size int) Option {
+	return func(c *AES) {
+		c.cache = nil
+		if 0 < size {
+			c.cache = newAEADCache(size)
+		}
+	}
+}
+
+type aeadCache struct {
+	mu    sync.Mutex
+	size  int
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type aeadCacheEntry struct {
+	key   string
+	aead  cipher.AEAD
+	nonce []byte
+}
+
+func newAEADCache(size int) *aeadCache {
+	return &aeadCache{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[string]*list.Element, size),
+	}
+}
+
+func (c *aeadCache) get(key string) (cipher.AEAD, []byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return nil, nil, false
+	}
+
+	c.ll.MoveToFront(e)
+	entry := e.Value.(*aeadCacheEntry)
+	return entry.aead, entry.nonce, true
+}
+
+func (c *aeadCache) add(key string, aead cipher.AEAD, nonce []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.items[key]; ok {
+		c.ll.MoveToFront(e)
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&aeadCacheEntry{key: key, aead: aead, nonce: nonce})
+	if c.size < c.ll.Len() {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*aeadCacheEntry).key)
+	}
+}
+
+func (c *aeadCache) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ll.Init()
+	clear(c.items)
+}
+
+func (c *aeadCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ll.Len()
+}
+
+// cacheKey returns unambiguous key of params and salt,
+// ikm is hashed so that the key material is not copied into map keys
+func (p aeadParams) cacheKey(salt []byte) string {
+	digest := sha256.Sum256(p.ikm)
+	b := make([]byte, 0, 3+len(digest)+len(salt))
+	b = append(b, byte(p.alg), byte(p.nonceLen))
+	if p.randomNonce {
+		b = append(b, 1)
+	} else {
+		b = append(b, 0)
+	}
+	b = append(b, digest[:]...)
+	b = append(b, salt...)
+	return string(b)
+}
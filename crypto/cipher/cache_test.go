@@ -0,0 +1,107 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAEADCache(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name string
+		opts []Option
+	}{
+		{
+			name: "Default",
+			opts: []Option{WithAEADCache(2)},
+		},
+		{
+			name: "RandomNonce",
+			opts: []Option{WithAEADCache(2), WithRandomNonce()},
+		},
+		{
+			name: "Envelope",
+			opts: []Option{WithAEADCache(2), WithEnvelope(), WithRandomNonce()},
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			secret := newRandHex(t, 16)
+			plaintext := newRandBytes(t, 128)
+			cached := NewAES(secret, v.opts...)
+			uncached := NewAES(secret, v.opts[1:]...)
+
+			// when
+			var wg sync.WaitGroup
+			for i := range 3 {
+				salt := cached.NewInt64Salt(int64(i))
+				for range 8 {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+
+						ciphertext, err := cached.Encrypt(plaintext, salt)
+						assert.NoError(t, err)
+
+						rettext, err := uncached.Decrypt(ciphertext, salt)
+						assert.NoError(t, err)
+						assert.Equal(t, plaintext, rettext)
+					}()
+				}
+			}
+			wg.Wait()
+
+			// then
+			assert.Equal(t, 2, cached.cache.len())
+		})
+	}
+}
+
+func TestAEADCacheKey(t *testing.T) {
+	// given
+	ikm := newRandBytes(t, 32)
+	salt := newRandBytes(t, 16)
+	p := aeadParams{ikm: ikm, alg: AlgorithmAES256, nonceLen: 12}
+
+	// when
+	key := p.cacheKey(salt)
+	other := aeadParams{ikm: newRandBytes(t, 32), alg: AlgorithmAES256, nonceLen: 12}.cacheKey(salt)
+
+	// then
+	assert.NotContains(t, key, string(ikm))
+	assert.NotEqual(t, key, other)
+	assert.Equal(t, key, p.cacheKey(salt))
+}
+
+func BenchmarkDecrypt(b *testing.B) {
+	benchmarkDecrypt(b)
+}
+
+func BenchmarkDecryptAEADCache(b *testing.B) {
+	benchmarkDecrypt(b, WithAEADCache(128))
+}
+
+func benchmarkDecrypt(b *testing.B, opts ...Option) {
+	c := NewAES("benchmark-secret", opts...)
+	salt := c.NewInt64Salt(42)
+	ciphertext, err := c.Encrypt(make([]byte, 64), salt)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := c.Decrypt(ciphertext, salt); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChaCha20Poly1305(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name string
+		opts []Option
+	}{
+		{
+			name: "ChaCha20Poly1305",
+			opts: []Option{WithChaCha20Poly1305()},
+		},
+		{
+			name: "XChaCha20Poly1305",
+			opts: []Option{WithXChaCha20Poly1305()},
+		},
+		{
+			name: "XChaCha20Poly1305Options",
+			opts: []Option{
+				WithXChaCha20Poly1305(),
+				WithRandomNonce(),
+				WithHKDFHash(sha512.New),
+				WithHKDFInfo([]byte("test-info")),
+			},
+		},
+		{
+			name: "ChaCha20Poly1305Envelope",
+			opts: []Option{WithChaCha20Poly1305(), WithEnvelope()},
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			secret := newRandHex(t, 16)
+			plaintext := newRandBytes(t, 128)
+			c := NewAES(secret, v.opts...)
+			salt := c.NewInt64Salt(42)
+
+			// when
+			ciphertext, err := c.Encrypt(plaintext, salt)
+			assert.NoError(t, err)
+
+			rettext, err := c.Decrypt(ciphertext, salt)
+			assert.NoError(t, err)
+
+			var buf bytes.Buffer
+			w, err := c.NewEncryptWriter(&buf, salt)
+			assert.NoError(t, err)
+			_, err = w.Write(plaintext)
+			assert.NoError(t, err)
+			assert.NoError(t, w.Close())
+
+			r, err := c.NewDecryptReader(&buf, salt)
+			assert.NoError(t, err)
+			streamtext, err := io.ReadAll(r)
+			assert.NoError(t, err)
+
+			// then
+			assert.Equal(t, plaintext, rettext)
+			assert.Equal(t, plaintext, streamtext)
+		})
+	}
+}
+
+func TestChaCha20Poly1305InvalidNonceLength(t *testing.T) {
+	// given
+	c := NewAES(newRandHex(t, 16), WithChaCha20Poly1305(), WithNonceLength(16))
+
+	// when
+	_, err := c.Encrypt([]byte("plaintext"), c.NewInt64Salt(42))
+
+	// then
+	assert.Error(t, err)
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidEncoding is returned when text ciphertext cannot be decoded
+var ErrInvalidEncoding = errors.New("cipher: invalid ciphertext encoding")
+
+// Encoding encodes ciphertext as text, *base64.Encoding implements it
+type Encoding interface {
+	EncodeToString(src []byte) string
+	DecodeString(s string) ([]byte, error)
+}
+
+var (
+	// StdBase64 is standard base64 encoding (RFC 4648) with padding
+	StdBase64 Encoding = base64.StdEncoding
+
+	// RawURLBase64 is URL-safe base64 encoding without padding, for URLs and tokens
+	RawURLBase64 Encoding = base64.RawURLEncoding
+
+	// Hex is lowercase hexadecimal encoding
+	Hex Encoding = hexEncoding{}
+)
+
+type hexEncoding struct{}
+
+func (hexEncoding) EncodeToString(src []byte) string {
+	return hex.EncodeToString(src)
+}
+
+func (hexEncoding) DecodeString(s string) ([]byte, error) {
+	return hex.DecodeString(s)
+}
+
+// WithEncoding configures text encoding of EncryptString and DecryptString, default is StdBase64
+func WithEncoding(e Encoding) Option {
+	return func(c *AES) {
+		c.encoding = e
+	}
+}
+
+// EncryptString implements encrypt plaintext and returns encoded ciphertext.
+// The decoded ciphertext equals Encrypt output.
+func (c *AES) EncryptString(plaintext string, salt []byte) (string, error) {
+	ciphertext, err := c.Encrypt([]byte(plaintext), salt)
+	if err != nil {
+		return "", err
+	}
+	return c.encoding.EncodeToString(ciphertext), nil
+}
+
+// DecryptString implements decode and decrypt ciphertext
+func (c *AES) DecryptString(ciphertext string, salt []byte) (string, error) {
+	decoded, err := c.encoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+
+	plaintext, err := c.Decrypt(decoded, salt)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptString(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name     string
+		encoding Encoding
+	}{
+		{
+			name:     "StdBase64",
+			encoding: StdBase64,
+		},
+		{
+			name:     "RawURLBase64",
+			encoding: RawURLBase64,
+		},
+		{
+			name:     "Hex",
+			encoding: Hex,
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			secret := newRandHex(t, 16)
+			plaintext := "user@example.com"
+			c := NewAES(secret, WithEncoding(v.encoding))
+			salt := c.NewInt64Salt(42)
+
+			// when
+			ciphertext, err := c.EncryptString(plaintext, salt)
+			assert.NoError(t, err)
+
+			rettext, err := c.DecryptString(ciphertext, salt)
+			assert.NoError(t, err)
+
+			raw, err := c.Encrypt([]byte(plaintext), salt)
+			assert.NoError(t, err)
+
+			_, errEncoding := c.DecryptString("!"+ciphertext, salt)
+
+			// then
+			assert.Equal(t, plaintext, rettext)
+			assert.Equal(t, v.encoding.EncodeToString(raw), ciphertext)
+			assert.ErrorIs(t, errEncoding, ErrInvalidEncoding)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// envelope header layout (big endian)
+//
+//	+---------+-----------+-------+----------+--------+
+//	| version | algorithm | flags | nonceLen | keyID  |
+//	| 1 byte  | 1 byte    | 1 byte| 1 byte   | 4 bytes|
+//	+---------+-----------+-------+----------+--------+
+//
+// Unknown flags are rejected. With flagPasswordKDF, the password kdf block
+// follows the header and is authenticated as a part of it.
+// The header is authenticated as additional data of the AEAD,
+// followed by the caller additional data.
+const (
+	envelopeVersion1  byte = 1
+	envelopeHeaderLen      = 8
+)
+
+// envelope header flags
+const (
+	// flagRandomNonce marks the nonce is prepended to the ciphertext
+	flagRandomNonce byte = 1 << iota
+
+	// flagPasswordKDF marks the password kdf block follows the header
+	flagPasswordKDF
+)
+
+var (
+	// ErrInvalidEnvelope is returned when ciphertext has a malformed envelope header
+	ErrInvalidEnvelope = errors.New("cipher: invalid envelope")
+
+	// ErrUnsupportedVersion is returned when envelope version is unknown
+	ErrUnsupportedVersion = errors.New("cipher: unsupported envelope version")
+
+	// ErrKeyIDMismatch is returned when envelope key id differs from the configured one
+	ErrKeyIDMismatch = errors.New("cipher: key id mismatch")
+)
+
+type envelopeHeader struct {
+	version  byte
+	alg      Algorithm
+	flags    byte
+	nonceLen int
+	keyID    uint32
+}
+
+func (h *envelopeHeader) marshal() []byte {
+	b := make([]byte, envelopeHeaderLen)
+	b[0] = h.version
+	b[1] = byte(h.alg)
+	b[2] = h.flags
+	b[3] = byte(h.nonceLen)
+	binary.BigEndian.PutUint32(b[4:], h.keyID)
+	return b
+}
+
+func parseEnvelopeHeader(b []byte) (*envelopeHeader, error) {
+	if len(b) < envelopeHeaderLen {
+		return nil, ErrInvalidEnvelope
+	}
+
+	h := &envelopeHeader{
+		version:  b[0],
+		alg:      Algorithm(b[1]),
+		flags:    b[2],
+		nonceLen: int(b[3]),
+		keyID:    binary.BigEndian.Uint32(b[4:]),
+	}
+	if h.version != envelopeVersion1 {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, h.version)
+	}
+	if h.alg.KeyLen() == 0 || h.nonceLen == 0 || h.flags&^(flagRandomNonce|flagPasswordKDF) != 0 {
+		return nil, ErrInvalidEnvelope
+	}
+	return h, nil
+}
+
+func (c *AES) sealEnvelope(plaintext, salt, aad []byte) ([]byte, error) {
+	if c.nonceLen <= 0 || 0xff < c.nonceLen {
+		return nil, fmt.Errorf("invalid nonce length: %d", c.nonceLen)
+	}
+
+	h := &envelopeHeader{
+		version:  envelopeVersion1,
+		alg:      c.alg,
+		nonceLen: c.nonceLen,
+		keyID:    c.keyID,
+	}
+	if c.randomNonce {
+		h.flags |= flagRandomNonce
+	}
+
+	p, err := c.params()
+	if err != nil {
+		return nil, err
+	}
+
+	var block []byte
+	if c.password != nil {
+		if block, p.ikm, err = c.password.stretch(p.ikm); err != nil {
+			return nil, err
+		}
+		h.flags |= flagPasswordKDF
+	}
+
+	header := append(h.marshal(), block...)
+	return c.seal(header, p, plaintext, salt, slices.Concat(header, aad))
+}
+
+func (c *AES) openEnvelope(ciphertext, salt, aad []byte) ([]byte, error) {
+	h, err := parseEnvelopeHeader(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	if h.keyID != c.keyID {
+		return nil, fmt.Errorf("%w: %d", ErrKeyIDMismatch, h.keyID)
+	}
+
+	p, err := c.params()
+	if err != nil {
+		return nil, err
+	}
+	p.alg = h.alg
+	p.nonceLen = h.nonceLen
+	p.randomNonce = h.flags&flagRandomNonce != 0
+
+	headerLen := envelopeHeaderLen
+	if h.flags&flagPasswordKDF != 0 {
+		headerLen += passwordBlockLen
+		if len(ciphertext) < headerLen {
+			return nil, ErrInvalidEnvelope
+		}
+		if p.ikm, err = c.stretchPassword(p.ikm, ciphertext[envelopeHeaderLen:headerLen]); err != nil {
+			return nil, err
+		}
+	}
+
+	header, body := ciphertext[:headerLen], ciphertext[headerLen:]
+	return c.open(p, body, salt, slices.Concat(header, aad))
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvelope(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name string
+		opts []Option
+	}{
+		{
+			name: "AES128",
+			opts: []Option{WithAES128()},
+		},
+		{
+			name: "AES192NonceLen16",
+			opts: []Option{WithAES192(), WithNonceLength(16)},
+		},
+		{
+			name: "AES256KeyID",
+			opts: []Option{WithAES256(), WithKeyID(7)},
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			secret := newRandHex(t, 16)
+			plaintext := newRandBytes(t, 128)
+			writer := NewAES(secret, append(v.opts, WithEnvelope())...)
+			reader := NewAES(secret, WithEnvelope(), WithKeyID(writer.keyID))
+
+			// when
+			salt := writer.NewInt64Salt(time.Now().Unix())
+			ciphertext, err := writer.Encrypt(plaintext, salt)
+			assert.NoError(t, err)
+
+			rettext, err := reader.Decrypt(ciphertext, salt)
+			assert.NoError(t, err)
+
+			// then
+			assert.Equal(t, envelopeVersion1, ciphertext[0])
+			assert.Equal(t, byte(writer.alg), ciphertext[1])
+			assert.Equal(t, plaintext, rettext)
+		})
+	}
+}
+
+func TestEnvelopeInvalid(t *testing.T) {
+	// given
+	secret := newRandHex(t, 16)
+	plaintext := newRandBytes(t, 64)
+	c := NewAES(secret, WithEnvelope(), WithKeyID(1))
+	salt := c.NewInt64Salt(1)
+	ciphertext, err := c.Encrypt(plaintext, salt)
+	assert.NoError(t, err)
+
+	// when
+	_, errShort := c.Decrypt(ciphertext[:4], salt)
+
+	version := append([]byte(nil), ciphertext...)
+	version[0] = 0xff
+	_, errVersion := c.Decrypt(version, salt)
+
+	_, errKeyID := NewAES(secret, WithEnvelope(), WithKeyID(2)).Decrypt(ciphertext, salt)
+
+	tampered := append([]byte(nil), ciphertext...)
+	tampered[3] = 16
+	_, errTampered := c.Decrypt(tampered, salt)
+
+	// then
+	assert.ErrorIs(t, errShort, ErrInvalidEnvelope)
+	assert.ErrorIs(t, errVersion, ErrUnsupportedVersion)
+	assert.ErrorIs(t, errKeyID, ErrKeyIDMismatch)
+	assert.Error(t, errTampered)
+}
@@ -0,0 +1,242 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+// Alphabets of FF1
+const (
+	// Digits is radix 10 alphabet (e.g. phone numbers, account numbers)
+	Digits = "0123456789"
+
+	// LowerAlphanumeric is radix 36 alphabet
+	LowerAlphanumeric = "0123456789abcdefghijklmnopqrstuvwxyz"
+)
+
+const (
+	ff1Rounds = 10
+
+	// ff1MinDomain is the minimum domain size radix^len of NIST SP 800-38G Rev.1
+	ff1MinDomain = 1_000_000
+
+	ff1MaxLen   = 1 << 16
+	ff1MaxTweak = 1 << 16
+)
+
+// ErrInvalidFPEInput is returned when FF1 input has characters out of the alphabet or invalid length
+var ErrInvalidFPEInput = errors.New("cipher: invalid fpe input")
+
+// FF1 implements NIST SP 800-38G FF1 format-preserving encryption.
+// Ciphertext has the same length and alphabet as plaintext, so it passes
+// format validation of downstream schemas. It is deterministic and not authenticated.
+// It is safe for concurrent use.
+type FF1 struct {
+	block    cipher.Block
+	alphabet []rune
+	index    map[rune]uint16
+	radix    int
+}
+
+// NewFF1 creates FF1 for name (e.g. "users.phone") with alphabet, the key is derived
+// per name through HKDF info. The radix is the alphabet length.
+// It returns ErrPasswordKDFUnsupported with WithArgon2id or WithScrypt.
+func (c *AES) NewFF1(name, alphabet string) (*FF1, error) {
+	key, err := c.deriveKey([]byte("ff1/"+name), 32)
+	if err != nil {
+		return nil, err
+	}
+	return newFF1(key, alphabet)
+}
+
+func newFF1(key []byte, alphabet string) (*FF1, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("new aes cipher: %w", err)
+	}
+
+	runes := []rune(alphabet)
+	if len(runes) < 2 || 1<<16 < len(runes) {
+		return nil, fmt.Errorf("invalid alphabet radix: %d", len(runes))
+	}
+
+	index := make(map[rune]uint16, len(runes))
+	for i, r := range runes {
+		if _, ok := index[r]; ok {
+			return nil, fmt.Errorf("duplicate alphabet character: %q", r)
+		}
+		index[r] = uint16(i)
+	}
+
+	return &FF1{
+		block:    block,
+		alphabet: runes,
+		index:    index,
+		radix:    len(runes),
+	}, nil
+}
+
+// Encrypt returns ciphertext of plaintext, tweak (e.g. a tenant id) is public
+// and changes the permutation
+func (f *FF1) Encrypt(plaintext string, tweak []byte) (string, error) {
+	return f.transform(plaintext, tweak, true)
+}
+
+// Decrypt returns plaintext of ciphertext
+func (f *FF1) Decrypt(ciphertext string, tweak []byte) (string, error) {
+	return f.transform(ciphertext, tweak, false)
+}
+
+func (f *FF1) transform(s string, tweak []byte, encrypt bool) (string, error) {
+	x, err := f.numerals(s)
+	if err != nil {
+		return "", err
+	}
+	if len(tweak) > ff1MaxTweak {
+		return "", fmt.Errorf("%w: tweak length %d", ErrInvalidFPEInput, len(tweak))
+	}
+
+	y := f.cipher(x, tweak, encrypt)
+
+	out := make([]rune, len(y))
+	for i, v := range y {
+		out[i] = f.alphabet[v]
+	}
+	return string(out), nil
+}
+
+func (f *FF1) numerals(s string) ([]uint16, error) {
+	x := make([]uint16, 0, len(s))
+	for _, r := range s {
+		v, ok := f.index[r]
+		if !ok {
+			return nil, fmt.Errorf("%w: character %q", ErrInvalidFPEInput, r)
+		}
+		x = append(x, v)
+	}
+
+	if len(x) < 2 || ff1MaxLen < len(x) {
+		return nil, fmt.Errorf("%w: length %d", ErrInvalidFPEInput, len(x))
+	}
+	domain := new(big.Int).Exp(big.NewInt(int64(f.radix)), big.NewInt(int64(len(x))), nil)
+	if domain.Cmp(big.NewInt(ff1MinDomain)) < 0 {
+		return nil, fmt.Errorf("%w: domain %s is too small", ErrInvalidFPEInput, domain)
+	}
+	return x, nil
+}
+
+// cipher implements FF1.Encrypt and FF1.Decrypt of NIST SP 800-38G 6.2
+func (f *FF1) cipher(x []uint16, tweak []byte, encrypt bool) []uint16 {
+	n, t := len(x), len(tweak)
+	u := n / 2
+	v := n - u
+	a, b := slices.Clone(x[:u]), slices.Clone(x[u:])
+
+	radix := big.NewInt(int64(f.radix))
+	radixU := new(big.Int).Exp(radix, big.NewInt(int64(u)), nil)
+	radixV := new(big.Int).Exp(radix, big.NewInt(int64(v)), nil)
+
+	// b bytes hold NUM_radix of v numerals: ceil(ceil(v*log2(radix))/8)
+	byteLen := (new(big.Int).Sub(radixV, big.NewInt(1)).BitLen() + 7) / 8
+	d := 4*((byteLen+3)/4) + 4
+
+	p := make([]byte, 16, 16+t+15+1+byteLen)
+	p[0], p[1], p[2] = 1, 2, 1
+	p[3], p[4], p[5] = byte(f.radix>>16), byte(f.radix>>8), byte(f.radix)
+	p[6], p[7] = 10, byte(u)
+	binary.BigEndian.PutUint32(p[8:], uint32(n))
+	binary.BigEndian.PutUint32(p[12:], uint32(t))
+
+	pad := (16 - (t+byteLen+1)%16) % 16
+	q := p
+	num, y, c := new(big.Int), new(big.Int), new(big.Int)
+	numBytes := make([]byte, byteLen)
+	s := make([]byte, (d+15)/16*16)
+
+	for k := range ff1Rounds {
+		i, src, dst := k, b, a
+		if !encrypt {
+			i, src, dst = ff1Rounds-1-k, a, b
+		}
+
+		// Q = T || 0^pad || [i]1 || [NUM_radix(src)]b
+		q = append(q[:16], tweak...)
+		q = append(q, make([]byte, pad)...)
+		q = append(q, byte(i))
+		f.num(num, src).FillBytes(numBytes)
+		q = append(q, numBytes...)
+
+		// R = PRF(P || Q), S = R || CIPH(R xor [1]16) || ...
+		f.prf(s[:16], q)
+		for j := 1; j*16 < d; j++ {
+			block := s[j*16 : (j+1)*16]
+			copy(block, s[:16])
+			binary.BigEndian.PutUint64(block[8:], binary.BigEndian.Uint64(block[8:])^uint64(j))
+			f.block.Encrypt(block, block)
+		}
+		y.SetBytes(s[:d])
+
+		m, mod := u, radixU
+		if i%2 == 1 {
+			m, mod = v, radixV
+		}
+
+		f.num(c, dst)
+		if encrypt {
+			c.Add(c, y)
+		} else {
+			c.Sub(c, y)
+		}
+		c.Mod(c, mod)
+
+		next := f.str(c, m)
+		if encrypt {
+			a, b = b, next
+		} else {
+			a, b = next, a
+		}
+	}
+	return append(a, b...)
+}
+
+// prf implements CBC-MAC of AES with zero IV, len(x) is a multiple of 16
+func (f *FF1) prf(dst, x []byte) {
+	clear(dst)
+	for i := 0; i < len(x); i += 16 {
+		subtle.XORBytes(dst, dst, x[i:i+16])
+		f.block.Encrypt(dst, dst)
+	}
+}
+
+// num returns NUM_radix of x, the first numeral is the most significant
+func (f *FF1) num(z *big.Int, x []uint16) *big.Int {
+	radix := big.NewInt(int64(f.radix))
+	z.SetInt64(0)
+	for _, v := range x {
+		z.Mul(z, radix)
+		z.Add(z, big.NewInt(int64(v)))
+	}
+	return z
+}
+
+// str returns STR^m_radix of z
+func (f *FF1) str(z *big.Int, m int) []uint16 {
+	radix := big.NewInt(int64(f.radix))
+	x := make([]uint16, m)
+	z, r := new(big.Int).Set(z), new(big.Int)
+	for i := m - 1; 0 <= i; i-- {
+		z.QuoRem(z, radix, r)
+		x[i] = uint16(r.Int64())
+	}
+	return x
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// NIST SP 800-38G FF1 samples
+func TestFF1Vectors(t *testing.T) {
+	// given
+	const (
+		key128 = "2B7E151628AED2A6ABF7158809CF4F3C"
+		key192 = key128 + "EF4359D8D580AA4F"
+		key256 = key192 + "7F036D6F04FC6A94"
+		tweak  = "39383736353433323130"
+		tweak2 = "3737373770717273373737"
+	)
+
+	// dataset
+	dataset := []struct {
+		name       string
+		key        string
+		alphabet   string
+		tweak      string
+		plaintext  string
+		ciphertext string
+	}{
+		{
+			name:       "Sample1",
+			key:        key128,
+			alphabet:   Digits,
+			plaintext:  "0123456789",
+			ciphertext: "2433477484",
+		},
+		{
+			name:       "Sample2",
+			key:        key128,
+			alphabet:   Digits,
+			tweak:      tweak,
+			plaintext:  "0123456789",
+			ciphertext: "6124200773",
+		},
+		{
+			name:       "Sample3",
+			key:        key128,
+			alphabet:   LowerAlphanumeric,
+			tweak:      tweak2,
+			plaintext:  "0123456789abcdefghi",
+			ciphertext: "a9tv40mll9kdu509eum",
+		},
+		{
+			name:       "Sample4",
+			key:        key192,
+			alphabet:   Digits,
+			plaintext:  "0123456789",
+			ciphertext: "2830668132",
+		},
+		{
+			name:       "Sample5",
+			key:        key192,
+			alphabet:   Digits,
+			tweak:      tweak,
+			plaintext:  "0123456789",
+			ciphertext: "2496655549",
+		},
+		{
+			name:       "Sample6",
+			key:        key192,
+			alphabet:   LowerAlphanumeric,
+			tweak:      tweak2,
+			plaintext:  "0123456789abcdefghi",
+			ciphertext: "xbj3kv35jrawxv32ysr",
+		},
+		{
+			name:       "Sample7",
+			key:        key256,
+			alphabet:   Digits,
+			plaintext:  "0123456789",
+			ciphertext: "6657667009",
+		},
+		{
+			name:       "Sample8",
+			key:        key256,
+			alphabet:   Digits,
+			tweak:      tweak,
+			plaintext:  "0123456789",
+			ciphertext: "1001623463",
+		},
+		{
+			name:       "Sample9",
+			key:        key256,
+			alphabet:   LowerAlphanumeric,
+			tweak:      tweak2,
+			plaintext:  "0123456789abcdefghi",
+			ciphertext: "xs8a0azh2avyalyzuwd",
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			f, err := newFF1(decodeHex(t, v.key), v.alphabet)
+			assert.NoError(t, err)
+			tweak := decodeHex(t, v.tweak)
+
+			// when
+			ciphertext, encryptErr := f.Encrypt(v.plaintext, tweak)
+			plaintext, decryptErr := f.Decrypt(v.ciphertext, tweak)
+
+			// then
+			assert.NoError(t, encryptErr)
+			assert.NoError(t, decryptErr)
+			assert.Equal(t, v.ciphertext, ciphertext)
+			assert.Equal(t, v.plaintext, plaintext)
+		})
+	}
+}
+
+func TestAESNewFF1(t *testing.T) {
+	// given
+	secret := newRandHex(t, 16)
+	phones, err := NewAES(secret).NewFF1("users.phone", Digits)
+	assert.NoError(t, err)
+	accounts, err := NewAES(secret).NewFF1("users.account", Digits)
+	assert.NoError(t, err)
+	hangul, err := NewAES(secret).NewFF1("users.nickname", "가나다라마바사아자차카타파하")
+	assert.NoError(t, err)
+
+	// dataset
+	dataset := []struct {
+		name      string
+		f         *FF1
+		plaintext string
+	}{
+		{name: "Phone", f: phones, plaintext: "01012345678"},
+		{name: "OddLength", f: phones, plaintext: "1234567"},
+		{name: "MultibyteAlphabet", f: hangul, plaintext: "가나다라마바사아"},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// when
+			ciphertext, err := v.f.Encrypt(v.plaintext, []byte("tenant-1"))
+			assert.NoError(t, err)
+			plaintext, err := v.f.Decrypt(ciphertext, []byte("tenant-1"))
+			assert.NoError(t, err)
+			other, err := v.f.Encrypt(v.plaintext, []byte("tenant-2"))
+			assert.NoError(t, err)
+
+			// then
+			assert.Equal(t, v.plaintext, plaintext)
+			assert.Equal(t, len([]rune(v.plaintext)), len([]rune(ciphertext)))
+			assert.NotEqual(t, v.plaintext, ciphertext)
+			assert.NotEqual(t, ciphertext, other)
+		})
+	}
+
+	a, err := phones.Encrypt("01012345678", nil)
+	assert.NoError(t, err)
+	b, err := accounts.Encrypt("01012345678", nil)
+	assert.NoError(t, err)
+	assert.NotEqual(t, a, b)
+}
+
+func TestFF1Errors(t *testing.T) {
+	// given
+	f, err := NewAES(newRandHex(t, 16)).NewFF1("users.phone", Digits)
+	assert.NoError(t, err)
+
+	// dataset
+	dataset := []struct {
+		name      string
+		plaintext string
+	}{
+		{name: "OutOfAlphabet", plaintext: "010-1234-5678"},
+		{name: "SmallDomain", plaintext: "12345"},
+		{name: "Empty", plaintext: ""},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// when
+			_, err := f.Encrypt(v.plaintext, nil)
+
+			// then
+			assert.ErrorIs(t, err, ErrInvalidFPEInput)
+		})
+	}
+
+	_, err = NewAES(newRandHex(t, 16)).NewFF1("invalid", "aa")
+	assert.Error(t, err)
+}
@@ -0,0 +1,231 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// AES-GCM-SIV (RFC 8452) nonce-misuse-resistant AEAD
+const (
+	gcmSIVNonceSize = 12
+	gcmSIVTagSize   = 16
+)
+
+var errGCMSIVOpen = errors.New("cipher: message authentication failed")
+
+type gcmSIV struct {
+	block  cipher.Block
+	keyLen int
+}
+
+func newGCMSIV(key []byte) (cipher.AEAD, error) {
+	if len(key) != 16 && len(key) != 32 {
+		return nil, fmt.Errorf("invalid aes-gcm-siv key length: %d", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("new aes cipher: %w", err)
+	}
+	return &gcmSIV{block: block, keyLen: len(key)}, nil
+}
+
+func (g *gcmSIV) NonceSize() int {
+	return gcmSIVNonceSize
+}
+
+func (g *gcmSIV) Overhead() int {
+	return gcmSIVTagSize
+}
+
+func (g *gcmSIV) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != gcmSIVNonceSize {
+		panic("cipher: incorrect nonce length given to AES-GCM-SIV")
+	}
+
+	authKey, encBlock := g.deriveKeys(nonce)
+	tag := g.tag(authKey, encBlock, nonce, plaintext, additionalData)
+
+	ret, out := sliceForAppend(dst, len(plaintext)+gcmSIVTagSize)
+	gcmSIVCTR(encBlock, tag, out, plaintext)
+	copy(out[len(plaintext):], tag[:])
+	return ret
+}
+
+func (g *gcmSIV) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != gcmSIVNonceSize {
+		panic("cipher: incorrect nonce length given to AES-GCM-SIV")
+	}
+	if len(ciphertext) < gcmSIVTagSize {
+		return nil, errGCMSIVOpen
+	}
+
+	var tag [gcmSIVTagSize]byte
+	n := len(ciphertext) - gcmSIVTagSize
+	copy(tag[:], ciphertext[n:])
+
+	authKey, encBlock := g.deriveKeys(nonce)
+	ret, out := sliceForAppend(dst, n)
+	gcmSIVCTR(encBlock, tag, out, ciphertext[:n])
+
+	expected := g.tag(authKey, encBlock, nonce, out, additionalData)
+	if subtle.ConstantTimeCompare(expected[:], tag[:]) != 1 {
+		clear(out)
+		return nil, errGCMSIVOpen
+	}
+	return ret, nil
+}
+
+// deriveKeys derives per-nonce message authentication and encryption keys
+func (g *gcmSIV) deriveKeys(nonce []byte) (authKey [16]byte, encBlock cipher.Block) {
+	var in, out [16]byte
+	copy(in[4:], nonce)
+
+	encKey := make([]byte, g.keyLen)
+	for i := range uint32(2 + g.keyLen/8) {
+		binary.LittleEndian.PutUint32(in[:4], i)
+		g.block.Encrypt(out[:], in[:])
+		if i < 2 {
+			copy(authKey[i*8:], out[:8])
+		} else {
+			copy(encKey[(i-2)*8:], out[:8])
+		}
+	}
+
+	// key length is valid, aes.NewCipher never fails
+	encBlock, _ = aes.NewCipher(encKey)
+	return authKey, encBlock
+}
+
+func (g *gcmSIV) tag(authKey [16]byte, encBlock cipher.Block, nonce, plaintext, additionalData []byte) [16]byte {
+	p := newPolyval(authKey)
+	p.update(additionalData)
+	p.update(plaintext)
+
+	var lengths [16]byte
+	binary.LittleEndian.PutUint64(lengths[:8], uint64(len(additionalData))*8)
+	binary.LittleEndian.PutUint64(lengths[8:], uint64(len(plaintext))*8)
+	p.update(lengths[:])
+
+	s := p.sum()
+	for i := range nonce {
+		s[i] ^= nonce[i]
+	}
+	s[15] &= 0x7f
+
+	var tag [16]byte
+	encBlock.Encrypt(tag[:], s[:])
+	return tag
+}
+
+func gcmSIVCTR(block cipher.Block, tag [16]byte, dst, src []byte) {
+	counter := tag
+	counter[15] |= 0x80
+
+	var stream [16]byte
+	for len(src) > 0 {
+		block.Encrypt(stream[:], counter[:])
+		n := subtle.XORBytes(dst, src, stream[:])
+		dst, src = dst[n:], src[n:]
+
+		ctr := binary.LittleEndian.Uint32(counter[:4]) + 1
+		binary.LittleEndian.PutUint32(counter[:4], ctr)
+	}
+}
+
+// polyval implements POLYVAL universal hash over GF(2^128) defined by
+// x^128 + x^127 + x^126 + x^121 + 1, elements are little endian.
+type polyval struct {
+	h fieldElement // key * x^-128, so dot(a, h) = a * h
+	s fieldElement
+}
+
+type fieldElement struct {
+	lo, hi uint64
+}
+
+// xInv128 is x^-128 in the POLYVAL field
+var xInv128 = func() fieldElement {
+	// x^-1 = x^127 + x^126 + x^125 + x^120
+	xInv := fieldElement{hi: 1<<63 | 1<<62 | 1<<61 | 1<<56}
+	ret := fieldElement{lo: 1}
+	for range 128 {
+		ret = ret.mul(xInv)
+	}
+	return ret
+}()
+
+func newPolyval(key [16]byte) *polyval {
+	return &polyval{h: loadFieldElement(key[:]).mul(xInv128)}
+}
+
+// update absorbs data padded with zeros to the block size
+func (p *polyval) update(data []byte) {
+	var block [16]byte
+	for len(data) > 0 {
+		n := copy(block[:], data)
+		clear(block[n:])
+		data = data[n:]
+
+		x := loadFieldElement(block[:])
+		p.s = fieldElement{lo: p.s.lo ^ x.lo, hi: p.s.hi ^ x.hi}.mul(p.h)
+	}
+}
+
+func (p *polyval) sum() [16]byte {
+	var ret [16]byte
+	binary.LittleEndian.PutUint64(ret[:8], p.s.lo)
+	binary.LittleEndian.PutUint64(ret[8:], p.s.hi)
+	return ret
+}
+
+func loadFieldElement(b []byte) fieldElement {
+	return fieldElement{
+		lo: binary.LittleEndian.Uint64(b[:8]),
+		hi: binary.LittleEndian.Uint64(b[8:16]),
+	}
+}
+
+// mul returns a * b mod P in constant time
+func (a fieldElement) mul(b fieldElement) fieldElement {
+	var ret fieldElement
+	for i := 127; 0 <= i; i-- {
+		// ret = ret * x mod P
+		carry := -(ret.hi >> 63)
+		ret.hi = ret.hi<<1 | ret.lo>>63
+		ret.lo = ret.lo<<1 ^ carry&1
+		ret.hi ^= carry & (1<<63 | 1<<62 | 1<<57)
+
+		// ret += a if bit i of b is set
+		var bit uint64
+		if 64 <= i {
+			bit = b.hi >> (i - 64) & 1
+		} else {
+			bit = b.lo >> i & 1
+		}
+		mask := -bit
+		ret.lo ^= a.lo & mask
+		ret.hi ^= a.hi & mask
+	}
+	return ret
+}
+
+// sliceForAppend extends in by n bytes, returns the whole slice and the tail
+func sliceForAppend(in []byte, n int) (head, tail []byte) {
+	if total := len(in) + n; cap(in) >= total {
+		head = in[:total]
+	} else {
+		head = make([]byte, total)
+		copy(head, in)
+	}
+	tail = head[len(in):]
+	return head, tail
+}
@@ -0,0 +1,257 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolyval(t *testing.T) {
+	// given (RFC 8452 Appendix A)
+	var key [16]byte
+	copy(key[:], decodeHex(t, "25629347589242761d31f826ba4b757b"))
+	data := decodeHex(t, "4f4f95668c83dfb6401762bb2d01a262d1a24ddd2721d006bbe45f20d3c9f362")
+
+	// when
+	p := newPolyval(key)
+	p.update(data)
+	sum := p.sum()
+
+	// then
+	assert.Equal(t, "f7a3b47b846119fae5b7866cf5e5b77e", hex.EncodeToString(sum[:]))
+}
+
+func TestGCMSIVVectors(t *testing.T) {
+	// dataset (RFC 8452 Appendix C)
+	dataset := []struct {
+		name      string
+		key       string
+		nonce     string
+		plaintext string
+		aad       string
+		result    string
+	}{
+		{
+			name:   "AES128Empty",
+			key:    "01000000000000000000000000000000",
+			nonce:  "030000000000000000000000",
+			result: "dc20e2d83f25705bb49e439eca56de25",
+		},
+		{
+			name:      "AES128Plaintext8",
+			key:       "01000000000000000000000000000000",
+			nonce:     "030000000000000000000000",
+			plaintext: "0100000000000000",
+			result:    "b5d839330ac7b786578782fff6013b815b287c22493a364c",
+		},
+		{
+			name:      "AES128Plaintext12",
+			key:       "01000000000000000000000000000000",
+			nonce:     "030000000000000000000000",
+			plaintext: "010000000000000000000000",
+			result:    "7323ea61d05932260047d942a4978db357391a0bc4fdec8b0d106639",
+		},
+		{
+			name:      "AES128AADPlaintext8",
+			key:       "01000000000000000000000000000000",
+			nonce:     "030000000000000000000000",
+			plaintext: "0200000000000000",
+			aad:       "01",
+			result:    "1e6daba35669f4273b0a1a2560969cdf790d99759abd1508",
+		},
+		{
+			name:      "AES128AADPlaintext12",
+			key:       "01000000000000000000000000000000",
+			nonce:     "030000000000000000000000",
+			plaintext: "020000000000000000000000",
+			aad:       "01",
+			result:    "296c7889fd99f41917f4462008299c5102745aaa3a0c469fad9e075a",
+		},
+		{
+			name:      "AES128AADPlaintext16",
+			key:       "01000000000000000000000000000000",
+			nonce:     "030000000000000000000000",
+			plaintext: "02000000000000000000000000000000",
+			aad:       "01",
+			result:    "e2b0c5da79a901c1745f700525cb335b8f8936ec039e4e4bb97ebd8c4457441f",
+		},
+		{
+			name:      "AES128AADPlaintext32",
+			key:       "01000000000000000000000000000000",
+			nonce:     "030000000000000000000000",
+			plaintext: "0200000000000000000000000000000003000000000000000000000000000000",
+			aad:       "01",
+			result:    "620048ef3c1e73e57e02bb8562c416a319e73e4caac8e96a1ecb2933145a1d71e6af6a7f87287da059a71684ed3498e1",
+		},
+		{
+			name:      "AES128AADPlaintext48",
+			key:       "01000000000000000000000000000000",
+			nonce:     "030000000000000000000000",
+			plaintext: "020000000000000000000000000000000300000000000000000000000000000004000000000000000000000000000000",
+			aad:       "01",
+			result:    "50c8303ea93925d64090d07bd109dfd9515a5a33431019c17d93465999a8b0053201d723120a8562b838cdff25bf9d1e6a8cc3865f76897c2e4b245cf31c51f2",
+		},
+		{
+			name:      "AES128AADPlaintext64",
+			key:       "01000000000000000000000000000000",
+			nonce:     "030000000000000000000000",
+			plaintext: "02000000000000000000000000000000030000000000000000000000000000000400000000000000000000000000000005000000000000000000000000000000",
+			aad:       "01",
+			result:    "2f5c64059db55ee0fb847ed513003746aca4e61c711b5de2e7a77ffd02da42feec601910d3467bb8b36ebbaebce5fba30d36c95f48a3e7980f0e7ac299332a80cdc46ae475563de037001ef84ae21744",
+		},
+		{
+			name:   "AES256Empty",
+			key:    "0100000000000000000000000000000000000000000000000000000000000000",
+			nonce:  "030000000000000000000000",
+			result: "07f5f4169bbf55a8400cd47ea6fd400f",
+		},
+		{
+			name:      "AES256Plaintext8",
+			key:       "0100000000000000000000000000000000000000000000000000000000000000",
+			nonce:     "030000000000000000000000",
+			plaintext: "0100000000000000",
+			result:    "c2ef328e5c71c83b843122130f7364b761e0b97427e3df28",
+		},
+		{
+			name:      "AES256AADPlaintext8",
+			key:       "0100000000000000000000000000000000000000000000000000000000000000",
+			nonce:     "030000000000000000000000",
+			plaintext: "0200000000000000",
+			aad:       "01",
+			result:    "1de22967237a813291213f267e3b452f02d01ae33e4ec854",
+		},
+		{
+			name:      "AES256AADPlaintext12",
+			key:       "0100000000000000000000000000000000000000000000000000000000000000",
+			nonce:     "030000000000000000000000",
+			plaintext: "020000000000000000000000",
+			aad:       "01",
+			result:    "163d6f9cc1b346cd453a2e4cc1a4a19ae800941ccdc57cc8413c277f",
+		},
+		{
+			name:      "AES256AADPlaintext16",
+			key:       "0100000000000000000000000000000000000000000000000000000000000000",
+			nonce:     "030000000000000000000000",
+			plaintext: "02000000000000000000000000000000",
+			aad:       "01",
+			result:    "c91545823cc24f17dbb0e9e807d5ec17b292d28ff61189e8e49f3875ef91aff7",
+		},
+		{
+			name:      "AES256AADPlaintext32",
+			key:       "0100000000000000000000000000000000000000000000000000000000000000",
+			nonce:     "030000000000000000000000",
+			plaintext: "0200000000000000000000000000000003000000000000000000000000000000",
+			aad:       "01",
+			result:    "07dad364bfc2b9da89116d7bef6daaaf6f255510aa654f920ac81b94e8bad365aea1bad12702e1965604374aab96dbbc",
+		},
+		{
+			name:      "AES256AADPlaintext48",
+			key:       "0100000000000000000000000000000000000000000000000000000000000000",
+			nonce:     "030000000000000000000000",
+			plaintext: "020000000000000000000000000000000300000000000000000000000000000004000000000000000000000000000000",
+			aad:       "01",
+			result:    "c67a1f0f567a5198aa1fcc8e3f21314336f7f51ca8b1af61feac35a86416fa47fbca3b5f749cdf564527f2314f42fe2503332742b228c647173616cfd44c54eb",
+		},
+		{
+			name:      "AES256AADPlaintext64",
+			key:       "0100000000000000000000000000000000000000000000000000000000000000",
+			nonce:     "030000000000000000000000",
+			plaintext: "02000000000000000000000000000000030000000000000000000000000000000400000000000000000000000000000005000000000000000000000000000000",
+			aad:       "01",
+			result:    "67fd45e126bfb9a79930c43aad2d36967d3f0e4d217c1e551f59727870beefc98cb933a8fce9de887b1e40799988db1fc3f91880ed405b2dd298318858467c895bde0285037c5de81e5b570a049b62a0",
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			aead, err := newGCMSIV(decodeHex(t, v.key))
+			assert.NoError(t, err)
+			nonce := decodeHex(t, v.nonce)
+			aad := decodeHex(t, v.aad)
+
+			// when
+			ciphertext := aead.Seal(nil, nonce, decodeHex(t, v.plaintext), aad)
+			plaintext, err := aead.Open(nil, nonce, ciphertext, aad)
+			assert.NoError(t, err)
+
+			// then
+			assert.Equal(t, v.result, hex.EncodeToString(ciphertext))
+			assert.Equal(t, v.plaintext, hex.EncodeToString(plaintext))
+		})
+	}
+}
+
+func decodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return b
+}
+
+func TestAESGCMSIV(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name string
+		opts []Option
+	}{
+		{
+			name: "AES128GCMSIV",
+			opts: []Option{WithAES128GCMSIV()},
+		},
+		{
+			name: "AES256GCMSIV",
+			opts: []Option{WithAES256GCMSIV()},
+		},
+		{
+			name: "AES256GCMSIVEnvelope",
+			opts: []Option{WithAES256GCMSIV(), WithEnvelope()},
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			secret := newRandHex(t, 16)
+			plaintext1 := newRandBytes(t, 100)
+			plaintext2 := newRandBytes(t, 100)
+			c := NewAES(secret, v.opts...)
+			salt := c.NewInt64Salt(42)
+
+			// when
+			ciphertext1, err := c.Encrypt(plaintext1, salt)
+			assert.NoError(t, err)
+
+			ciphertext2, err := c.Encrypt(plaintext2, salt)
+			assert.NoError(t, err)
+
+			again, err := c.Encrypt(plaintext1, salt)
+			assert.NoError(t, err)
+
+			rettext, err := c.Decrypt(ciphertext1, salt)
+			assert.NoError(t, err)
+
+			tampered := append([]byte(nil), ciphertext1...)
+			tampered[len(tampered)-1] ^= 1
+			_, errTampered := c.Decrypt(tampered, salt)
+
+			// then
+			assert.Equal(t, plaintext1, rettext)
+			assert.Equal(t, ciphertext1, again)
+			assert.NotEqual(t, ciphertext1[:len(plaintext1)], ciphertext2[:len(plaintext2)])
+			assert.Error(t, errTampered)
+		})
+	}
+}
+
+func BenchmarkDecryptGCMSIV(b *testing.B) {
+	benchmarkDecrypt(b, WithAES256GCMSIV())
+}
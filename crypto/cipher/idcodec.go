@@ -0,0 +1,160 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+const (
+	base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// IDLen is the length of encoded int64 ids, 62^11 > 2^64
+	IDLen = 11
+
+	// ID32Len is the length of encoded int32 ids, 62^6 > 2^32
+	ID32Len = 6
+
+	// feistel rounds of 64-bit and 32-bit blocks
+	idRounds   = 8
+	id32Rounds = 12
+)
+
+// ErrInvalidID is returned when public id is malformed
+var ErrInvalidID = errors.New("cipher: invalid id")
+
+// IDCodec encrypts int64 and int32 ids into fixed-length base62 public ids
+// and decrypts them back. It is a keyed permutation (Feistel network with AES
+// round function) of the id space, so distinct ids never collide and
+// sequential ids look random. It is not authenticated, every well-formed
+// public id decodes to some id, then look it up before trusting it.
+// It is safe for concurrent use.
+type IDCodec struct {
+	block cipher.Block
+}
+
+// NewIDCodec creates IDCodec for entity (e.g. "user"), the key is derived
+// per entity through HKDF info, so equal ids of different entities differ.
+// Decoded ids fit the NewInt64Salt and NewInt32Salt use cases.
+// It returns ErrPasswordKDFUnsupported with WithArgon2id or WithScrypt.
+func (c *AES) NewIDCodec(entity string) (*IDCodec, error) {
+	key, err := c.deriveKey([]byte("id/"+entity), 32)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("new aes cipher: %w", err)
+	}
+	return &IDCodec{block: block}, nil
+}
+
+// Encode returns IDLen characters public id of id
+func (c *IDCodec) Encode(id int64) string {
+	return encodeBase62(c.feistel(uint64(id), 64, idRounds, false), IDLen)
+}
+
+// Decode returns id of public id
+func (c *IDCodec) Decode(s string) (int64, error) {
+	v, err := decodeBase62(s, IDLen)
+	if err != nil {
+		return 0, err
+	}
+	return int64(c.feistel(v, 64, idRounds, true)), nil
+}
+
+// Encode32 returns ID32Len characters public id of id
+func (c *IDCodec) Encode32(id int32) string {
+	return encodeBase62(c.feistel(uint64(uint32(id)), 32, id32Rounds, false), ID32Len)
+}
+
+// Decode32 returns id of public id
+func (c *IDCodec) Decode32(s string) (int32, error) {
+	v, err := decodeBase62(s, ID32Len)
+	if err != nil {
+		return 0, err
+	}
+	if v > 0xffffffff {
+		return 0, fmt.Errorf("%w: out of range", ErrInvalidID)
+	}
+	return int32(uint32(c.feistel(v, 32, id32Rounds, true))), nil
+}
+
+// feistel permutes width bits block of v with balanced Feistel network
+func (c *IDCodec) feistel(v uint64, width, rounds int, inverse bool) uint64 {
+	half := uint(width / 2)
+	mask := uint64(1)<<half - 1
+	l, r := v>>half&mask, v&mask
+
+	for i := range rounds {
+		round := i
+		if inverse {
+			round = rounds - 1 - i
+			l, r = r^c.round(round, width, l)&mask, l
+			continue
+		}
+		l, r = r, l^c.round(round, width, r)&mask
+	}
+	return l<<half | r
+}
+
+// round returns AES of round number, block width and half block as pseudorandom function
+func (c *IDCodec) round(round, width int, half uint64) uint64 {
+	var b [aes.BlockSize]byte
+	b[0] = byte(round)
+	b[1] = byte(width)
+	binary.BigEndian.PutUint64(b[8:], half)
+	c.block.Encrypt(b[:], b[:])
+	return binary.BigEndian.Uint64(b[8:])
+}
+
+func encodeBase62(v uint64, n int) string {
+	b := make([]byte, n)
+	for i := n - 1; 0 <= i; i-- {
+		b[i] = base62Alphabet[v%62]
+		v /= 62
+	}
+	return string(b)
+}
+
+func decodeBase62(s string, n int) (uint64, error) {
+	if len(s) != n {
+		return 0, fmt.Errorf("%w: length %d", ErrInvalidID, len(s))
+	}
+
+	var v uint64
+	for i := range len(s) {
+		d := base62Index(s[i])
+		if d < 0 {
+			return 0, fmt.Errorf("%w: character %q", ErrInvalidID, s[i])
+		}
+
+		hi, lo := bits.Mul64(v, 62)
+		lo, carry := bits.Add64(lo, uint64(d), 0)
+		if hi != 0 || carry != 0 {
+			return 0, fmt.Errorf("%w: out of range", ErrInvalidID)
+		}
+		v = lo
+	}
+	return v, nil
+}
+
+func base62Index(ch byte) int {
+	switch {
+	case '0' <= ch && ch <= '9':
+		return int(ch - '0')
+	case 'A' <= ch && ch <= 'Z':
+		return int(ch-'A') + 10
+	case 'a' <= ch && ch <= 'z':
+		return int(ch-'a') + 36
+	}
+	return -1
+}
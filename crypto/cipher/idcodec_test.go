@@ -0,0 +1,152 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDCodecEncode(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name string
+		id   int64
+	}{
+		{name: "Zero", id: 0},
+		{name: "One", id: 1},
+		{name: "Sequential", id: 1000001},
+		{name: "Negative", id: -1},
+		{name: "Max", id: math.MaxInt64},
+		{name: "Min", id: math.MinInt64},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			c, err := NewAES(newRandHex(t, 16)).NewIDCodec("user")
+			assert.NoError(t, err)
+
+			// when
+			s := c.Encode(v.id)
+			id, err := c.Decode(s)
+
+			// then
+			assert.NoError(t, err)
+			assert.Len(t, s, IDLen)
+			assert.Equal(t, v.id, id)
+		})
+	}
+}
+
+func TestIDCodecEncode32(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name string
+		id   int32
+	}{
+		{name: "Zero", id: 0},
+		{name: "One", id: 1},
+		{name: "Negative", id: -1},
+		{name: "Max", id: math.MaxInt32},
+		{name: "Min", id: math.MinInt32},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			c, err := NewAES(newRandHex(t, 16)).NewIDCodec("user")
+			assert.NoError(t, err)
+
+			// when
+			s := c.Encode32(v.id)
+			id, err := c.Decode32(s)
+
+			// then
+			assert.NoError(t, err)
+			assert.Len(t, s, ID32Len)
+			assert.Equal(t, v.id, id)
+		})
+	}
+}
+
+func TestIDCodecUnique(t *testing.T) {
+	// given
+	secret := newRandHex(t, 16)
+	users, err := NewAES(secret).NewIDCodec("user")
+	assert.NoError(t, err)
+	orders, err := NewAES(secret).NewIDCodec("order")
+	assert.NoError(t, err)
+	again, err := NewAES(secret).NewIDCodec("user")
+	assert.NoError(t, err)
+
+	// when
+	seen := make(map[string]struct{})
+	for id := range int64(10000) {
+		seen[users.Encode(id)] = struct{}{}
+	}
+
+	// then
+	assert.Len(t, seen, 10000)
+	assert.NotEqual(t, users.Encode(1), orders.Encode(1))
+	assert.Equal(t, users.Encode(1), again.Encode(1))
+}
+
+func TestIDCodecDecode(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name string
+		s    string
+		fn   func(c *IDCodec, s string) error
+	}{
+		{
+			name: "Short",
+			s:    "abc",
+			fn:   decodeErr,
+		},
+		{
+			name: "InvalidCharacter",
+			s:    "abc-efghijk",
+			fn:   decodeErr,
+		},
+		{
+			name: "Overflow",
+			s:    "zzzzzzzzzzz",
+			fn:   decodeErr,
+		},
+		{
+			name: "Overflow32",
+			s:    "zzzzzz",
+			fn: func(c *IDCodec, s string) error {
+				_, err := c.Decode32(s)
+				return err
+			},
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			c, err := NewAES(newRandHex(t, 16)).NewIDCodec("user")
+			assert.NoError(t, err)
+
+			// when
+			err = v.fn(c, v.s)
+
+			// then
+			assert.ErrorIs(t, err, ErrInvalidID)
+		})
+	}
+}
+
+func decodeErr(c *IDCodec, s string) error {
+	_, err := c.Decode(s)
+	return err
+}
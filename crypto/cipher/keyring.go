@@ -0,0 +1,172 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+var (
+	// ErrKeyNotFound is returned when key id is not in the keyring
+	ErrKeyNotFound = errors.New("cipher: key not found")
+
+	// ErrKeyExists is returned when key id is already in the keyring
+	ErrKeyExists = errors.New("cipher: key already exists")
+
+	// ErrNoPrimaryKey is returned when the keyring has no primary key to encrypt
+	ErrNoPrimaryKey = errors.New("cipher: no primary key")
+
+	// ErrPrimaryKey is returned when retiring the primary key
+	ErrPrimaryKey = errors.New("cipher: primary key cannot be retired")
+)
+
+// Keyring holds several secrets under stable key ids to rotate them.
+// Encrypt uses the primary key and records its id in the envelope header,
+// Decrypt selects the key that wrote the ciphertext.
+// It is safe for concurrent use, Retire and Close wait for in-flight Encrypt and Decrypt.
+type Keyring struct {
+	mu         sync.RWMutex
+	opts       []Option
+	keys       map[uint32]*AES
+	primary    uint32
+	hasPrimary bool
+}
+
+// NewKeyring creates Keyring, opts are applied to every key
+func NewKeyring(opts ...Option) *Keyring {
+	return &Keyring{
+		opts: opts,
+		keys: make(map[uint32]*AES),
+	}
+}
+
+// Add adds secret as decrypt-only key, it is used to encrypt after Promote
+func (k *Keyring) Add(id uint32, secret string) error {
+	return k.AddSecret(id, NewSecret([]byte(secret)))
+}
+
+// AddSecret adds Secret as decrypt-only key, it is used to encrypt after Promote.
+// The keyring owns secret, it is closed on Retire or Close.
+func (k *Keyring) AddSecret(id uint32, secret *Secret) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if _, ok := k.keys[id]; ok {
+		return fmt.Errorf("%w: %d", ErrKeyExists, id)
+	}
+
+	opts := append(slices.Clone(k.opts), WithKeyID(id), WithEnvelope())
+	k.keys[id] = NewAESFromSecret(secret, opts...)
+	return nil
+}
+
+// Promote makes the key primary, the previous primary key remains decrypt-only
+func (k *Keyring) Promote(id uint32) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if _, ok := k.keys[id]; !ok {
+		return fmt.Errorf("%w: %d", ErrKeyNotFound, id)
+	}
+
+	k.primary = id
+	k.hasPrimary = true
+	return nil
+}
+
+// Retire removes and closes the key, ciphertexts written by it can no longer be decrypted
+func (k *Keyring) Retire(id uint32) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	c, ok := k.keys[id]
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrKeyNotFound, id)
+	}
+	if k.hasPrimary && k.primary == id {
+		return fmt.Errorf("%w: %d", ErrPrimaryKey, id)
+	}
+
+	delete(k.keys, id)
+	return c.Close()
+}
+
+// Close closes and removes every key, Keyring must not be used after Close
+func (k *Keyring) Close() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	var errs []error
+	for id, c := range k.keys {
+		errs = append(errs, c.Close())
+		delete(k.keys, id)
+	}
+	k.primary = 0
+	k.hasPrimary = false
+	return errors.Join(errs...)
+}
+
+// Primary returns the primary key id, ok is false if no key is promoted
+func (k *Keyring) Primary() (id uint32, ok bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.primary, k.hasPrimary
+}
+
+// KeyIDs returns sorted key ids in the keyring
+func (k *Keyring) KeyIDs() []uint32 {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	ids := make([]uint32, 0, len(k.keys))
+	for id := range k.keys {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// Encrypt implements encrypt and authenticates plaintext with the primary key
+func (k *Keyring) Encrypt(plaintext, salt []byte) ([]byte, error) {
+	return k.EncryptWithAAD(plaintext, salt, nil)
+}
+
+// Decrypt implements decrypt and authenticates ciphertext with the key that wrote it
+func (k *Keyring) Decrypt(ciphertext, salt []byte) ([]byte, error) {
+	return k.DecryptWithAAD(ciphertext, salt, nil)
+}
+
+// EncryptWithAAD implements encrypt and authenticates plaintext and additional data with the primary key
+func (k *Keyring) EncryptWithAAD(plaintext, salt, aad []byte) ([]byte, error) {
+	// the read lock is held while the key is used, so Retire and Close wait for it
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if !k.hasPrimary {
+		return nil, ErrNoPrimaryKey
+	}
+	return k.keys[k.primary].EncryptWithAAD(plaintext, salt, aad)
+}
+
+// DecryptWithAAD implements decrypt and authenticates ciphertext and additional data with the key that wrote it
+func (k *Keyring) DecryptWithAAD(ciphertext, salt, aad []byte) ([]byte, error) {
+	h, err := parseEnvelopeHeader(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	c, ok := k.keys[h.keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrKeyNotFound, h.keyID)
+	}
+	return c.DecryptWithAAD(ciphertext, salt, aad)
+}
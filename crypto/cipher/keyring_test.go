@@ -0,0 +1,151 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyringRotation(t *testing.T) {
+	// given
+	plaintext := newRandBytes(t, 128)
+	salt := NewAES("").NewInt64Salt(42)
+	k := NewKeyring(WithRandomNonce())
+	assert.NoError(t, k.Add(1, newRandHex(t, 16)))
+	assert.NoError(t, k.Promote(1))
+
+	// when
+	ciphertext1, err := k.Encrypt(plaintext, salt)
+	assert.NoError(t, err)
+
+	assert.NoError(t, k.Add(2, newRandHex(t, 16)))
+	assert.NoError(t, k.Promote(2))
+
+	ciphertext2, err := k.Encrypt(plaintext, salt)
+	assert.NoError(t, err)
+
+	rettext1, err := k.Decrypt(ciphertext1, salt)
+	assert.NoError(t, err)
+
+	rettext2, err := k.Decrypt(ciphertext2, salt)
+	assert.NoError(t, err)
+
+	assert.NoError(t, k.Retire(1))
+	_, errRetired := k.Decrypt(ciphertext1, salt)
+
+	// then
+	id, ok := k.Primary()
+	assert.True(t, ok)
+	assert.Equal(t, uint32(2), id)
+	assert.Equal(t, []uint32{2}, k.KeyIDs())
+	assert.Equal(t, plaintext, rettext1)
+	assert.Equal(t, plaintext, rettext2)
+	assert.ErrorIs(t, errRetired, ErrKeyNotFound)
+}
+
+func TestKeyringErrors(t *testing.T) {
+	// given
+	k := NewKeyring()
+
+	// when
+	_, errEncrypt := k.Encrypt([]byte("plaintext"), nil)
+	errPromote := k.Promote(1)
+	errAdd1 := k.Add(1, newRandHex(t, 16))
+	errAdd2 := k.Add(1, newRandHex(t, 16))
+	_ = k.Promote(1)
+	errRetire := k.Retire(1)
+
+	// then
+	assert.ErrorIs(t, errEncrypt, ErrNoPrimaryKey)
+	assert.ErrorIs(t, errPromote, ErrKeyNotFound)
+	assert.NoError(t, errAdd1)
+	assert.ErrorIs(t, errAdd2, ErrKeyExists)
+	assert.ErrorIs(t, errRetire, ErrPrimaryKey)
+}
+
+func TestKeyringClose(t *testing.T) {
+	// given
+	secret1 := NewSecret([]byte(newRandHex(t, 16)))
+	secret2 := NewSecret([]byte(newRandHex(t, 16)))
+	k := NewKeyring()
+	assert.NoError(t, k.AddSecret(1, secret1))
+	assert.NoError(t, k.AddSecret(2, secret2))
+	assert.NoError(t, k.Promote(2))
+
+	// when
+	errRetire := k.Retire(1)
+	_, errRetired := secret1.Bytes()
+
+	errClose := k.Close()
+	_, errClosed := secret2.Bytes()
+	_, errEncrypt := k.Encrypt([]byte("plaintext"), nil)
+
+	// then
+	assert.NoError(t, errRetire)
+	assert.ErrorIs(t, errRetired, ErrSecretClosed)
+	assert.NoError(t, errClose)
+	assert.ErrorIs(t, errClosed, ErrSecretClosed)
+	assert.ErrorIs(t, errEncrypt, ErrNoPrimaryKey)
+	assert.Empty(t, k.KeyIDs())
+}
+
+func TestKeyringConcurrentRetire(t *testing.T) {
+	// given
+	secrets := map[uint32]string{1: newRandHex(t, 16), 2: newRandHex(t, 16)}
+	salt := NewAES("").NewInt64Salt(42)
+	k := NewKeyring(WithRandomNonce())
+	assert.NoError(t, k.Add(1, secrets[1]))
+	assert.NoError(t, k.Promote(1))
+
+	done := make(chan struct{})
+	rotated := make(chan struct{})
+	go func() {
+		defer close(rotated)
+		from, to := uint32(1), uint32(2)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			assert.NoError(t, k.Add(to, secrets[to]))
+			assert.NoError(t, k.Promote(to))
+			assert.NoError(t, k.Retire(from))
+			from, to = to, from
+		}
+	}()
+
+	// when
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			plaintext := newRandBytes(t, 128)
+			for range 500 {
+				ciphertext, err := k.Encrypt(plaintext, salt)
+				if !assert.NoError(t, err) {
+					return
+				}
+				decrypted, err := k.Decrypt(ciphertext, salt)
+				if err != nil {
+					assert.ErrorIs(t, err, ErrKeyNotFound)
+					continue
+				}
+				assert.Equal(t, plaintext, decrypted)
+			}
+		}()
+	}
+	wg.Wait()
+	close(done)
+	<-rotated
+
+	// then
+	assert.Len(t, k.KeyIDs(), 1)
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomNonce(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name string
+		opts []Option
+	}{
+		{
+			name: "Raw",
+			opts: []Option{WithRandomNonce()},
+		},
+		{
+			name: "NonceLen16",
+			opts: []Option{WithRandomNonce(), WithNonceLength(16)},
+		},
+		{
+			name: "Envelope",
+			opts: []Option{WithRandomNonce(), WithEnvelope()},
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			secret := newRandHex(t, 16)
+			plaintext := newRandBytes(t, 128)
+			c := NewAES(secret, v.opts...)
+
+			// when
+			salt := c.NewInt64Salt(42)
+			ciphertext1, err := c.Encrypt(plaintext, salt)
+			assert.NoError(t, err)
+
+			ciphertext2, err := c.Encrypt(plaintext, salt)
+			assert.NoError(t, err)
+
+			rettext1, err := c.Decrypt(ciphertext1, salt)
+			assert.NoError(t, err)
+
+			rettext2, err := c.Decrypt(ciphertext2, salt)
+			assert.NoError(t, err)
+
+			// then
+			assert.NotEqual(t, ciphertext1, ciphertext2)
+			assert.Equal(t, plaintext, rettext1)
+			assert.Equal(t, plaintext, rettext2)
+		})
+	}
+}
+
+func TestRandomNonceEnvelopeReadsFlag(t *testing.T) {
+	// given
+	secret := newRandHex(t, 16)
+	plaintext := newRandBytes(t, 64)
+	writer := NewAES(secret, WithEnvelope(), WithRandomNonce())
+	reader := NewAES(secret, WithEnvelope())
+	salt := writer.NewInt64Salt(42)
+
+	// when
+	ciphertext, err := writer.Encrypt(plaintext, salt)
+	assert.NoError(t, err)
+
+	rettext, err := reader.Decrypt(ciphertext, salt)
+	assert.NoError(t, err)
+
+	_, errShort := NewAES(secret, WithRandomNonce()).Decrypt(ciphertext[:4], salt)
+
+	// then
+	assert.Equal(t, plaintext, rettext)
+	assert.Error(t, errShort)
+}
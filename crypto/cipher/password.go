@@ -0,0 +1,207 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// password kdf block layout (big endian), it follows the envelope header
+//
+//	+--------+---------+---------+---------+----------+
+//	| kdf id | param 1 | param 2 | param 3 | salt     |
+//	| 1 byte | 4 bytes | 4 bytes | 4 bytes | 16 bytes |
+//	+--------+---------+---------+---------+----------+
+//
+// Argon2id params are time, memory (KiB) and threads.
+// Scrypt params are N, r and p.
+const (
+	passwordKDFArgon2id byte = 1
+	passwordKDFScrypt   byte = 2
+
+	passwordSaltLen  = 16
+	passwordKeyLen   = 32
+	passwordBlockLen = 1 + 12 + passwordSaltLen
+
+	// upper bounds of cost params
+	maxArgon2Time    = 64
+	maxArgon2Memory  = 1024 * 1024 // 1 GiB
+	maxArgon2Threads = 255
+	maxScryptN       = 1 << 24
+	maxScryptRP      = 1 << 20
+	maxScryptMemory  = 256 << 20 // 128*N*r bytes
+
+	// stretched keys cached for decrypt
+	maxPasswordCache = 16
+)
+
+var (
+	// ErrInvalidPasswordKDF is returned when password kdf params are invalid
+	ErrInvalidPasswordKDF = errors.New("cipher: invalid password kdf params")
+
+	// ErrPasswordKDFUnsupported is returned when a feature without the envelope format
+	// is used with the password kdf
+	ErrPasswordKDFUnsupported = errors.New("cipher: password kdf is not supported")
+)
+
+// WithArgon2id configures stretching the secret with Argon2id before HKDF,
+// it is for human-supplied passphrases. The secret is stretched once with
+// a random salt, the params and the salt are stored in the envelope.
+// It configures the envelope format. Decrypt accepts only Argon2id params
+// not above the configured ones.
+// RFC 9106 recommends time=3, memory=64*1024 (64 MiB), threads=4 for constrained environments.
+func WithArgon2id(time, memory uint32, threads uint8) Option {
+	return func(c *AES) {
+		c.envelope = true
+		c.password = &passwordKDF{
+			id:     passwordKDFArgon2id,
+			params: [3]uint32{time, memory, uint32(threads)},
+		}
+	}
+}
+
+// WithScrypt configures stretching the secret with scrypt before HKDF,
+// it is for human-supplied passphrases. The secret is stretched once with
+// a random salt, the params and the salt are stored in the envelope.
+// It configures the envelope format. Decrypt accepts only scrypt params
+// not above the configured ones.
+// The scrypt paper recommends N=32768, r=8, p=1 for interactive use.
+func WithScrypt(n, r, p uint32) Option {
+	return func(c *AES) {
+		c.envelope = true
+		c.password = &passwordKDF{
+			id:     passwordKDFScrypt,
+			params: [3]uint32{n, r, p},
+		}
+	}
+}
+
+// passwordKDF stretches the secret once for encrypt
+type passwordKDF struct {
+	id     byte
+	params [3]uint32
+
+	once  sync.Once
+	block []byte
+	key   []byte
+	err   error
+}
+
+func (p *passwordKDF) stretch(secret []byte) (block, key []byte, err error) {
+	p.once.Do(func() {
+		block := make([]byte, passwordBlockLen)
+		block[0] = p.id
+		for i, v := range p.params {
+			binary.BigEndian.PutUint32(block[1+i*4:], v)
+		}
+		if _, err := io.ReadFull(rand.Reader, block[13:]); err != nil {
+			p.err = fmt.Errorf("read random password salt: %w", err)
+			return
+		}
+
+		p.block = block
+		p.key, p.err = stretchPassword(secret, block)
+	})
+	return p.block, p.key, p.err
+}
+
+// accepts reports whether the kdf block read from ciphertext does not exceed the configured cost
+func (p *passwordKDF) accepts(block []byte) error {
+	if len(block) != passwordBlockLen || block[0] != p.id {
+		return ErrInvalidPasswordKDF
+	}
+	for i, v := range p.params {
+		if n := binary.BigEndian.Uint32(block[1+i*4:]); v < n {
+			return fmt.Errorf("%w: param %d exceeds the configured cost: %d", ErrInvalidPasswordKDF, i+1, n)
+		}
+	}
+	return nil
+}
+
+// passwordCache caches keys stretched for decrypt by kdf block
+type passwordCache struct {
+	mu   sync.Mutex
+	keys map[string][]byte
+}
+
+func (c *AES) stretchPassword(secret, block []byte) ([]byte, error) {
+	if c.password == nil {
+		return nil, fmt.Errorf("%w: password kdf is not configured", ErrInvalidPasswordKDF)
+	}
+	if err := c.password.accepts(block); err != nil {
+		return nil, err
+	}
+
+	c.passwordCache.mu.Lock()
+	key, ok := c.passwordCache.keys[string(block)]
+	c.passwordCache.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+
+	key, err := stretchPassword(secret, block)
+	if err != nil {
+		return nil, err
+	}
+
+	c.passwordCache.mu.Lock()
+	defer c.passwordCache.mu.Unlock()
+
+	if c.passwordCache.keys == nil || maxPasswordCache <= len(c.passwordCache.keys) {
+		c.passwordCache.keys = make(map[string][]byte)
+	}
+	c.passwordCache.keys[string(block)] = key
+	return key, nil
+}
+
+func (c *passwordCache) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range c.keys {
+		clear(key)
+	}
+	c.keys = nil
+}
+
+func stretchPassword(secret, block []byte) ([]byte, error) {
+	if len(block) != passwordBlockLen {
+		return nil, ErrInvalidPasswordKDF
+	}
+
+	p1 := binary.BigEndian.Uint32(block[1:])
+	p2 := binary.BigEndian.Uint32(block[5:])
+	p3 := binary.BigEndian.Uint32(block[9:])
+	salt := block[13:]
+
+	switch block[0] {
+	case passwordKDFArgon2id:
+		if p1 == 0 || maxArgon2Time < p1 || p2 < 8*p3 || maxArgon2Memory < p2 || p3 == 0 || maxArgon2Threads < p3 {
+			return nil, fmt.Errorf("%w: argon2id time=%d memory=%d threads=%d", ErrInvalidPasswordKDF, p1, p2, p3)
+		}
+		return argon2.IDKey(secret, salt, p1, p2, uint8(p3), passwordKeyLen), nil
+
+	case passwordKDFScrypt:
+		if p1 < 2 || maxScryptN < p1 || p1&(p1-1) != 0 || p2 == 0 || p3 == 0 || maxScryptRP < uint64(p2)*uint64(p3) ||
+			maxScryptMemory < 128*uint64(p1)*uint64(p2) {
+			return nil, fmt.Errorf("%w: scrypt N=%d r=%d p=%d", ErrInvalidPasswordKDF, p1, p2, p3)
+		}
+
+		key, err := scrypt.Key(secret, salt, int(p1), int(p2), int(p3), passwordKeyLen)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPasswordKDF, err)
+		}
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: unknown kdf id: %d", ErrInvalidPasswordKDF, block[0])
+}
@@ -0,0 +1,197 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordKDF(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name string
+		opt  Option
+		id   byte
+	}{
+		{
+			name: "Argon2id",
+			opt:  WithArgon2id(1, 8*1024, 1),
+			id:   passwordKDFArgon2id,
+		},
+		{
+			name: "Scrypt",
+			opt:  WithScrypt(1024, 8, 1),
+			id:   passwordKDFScrypt,
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			passphrase := "correct horse battery staple"
+			plaintext := newRandBytes(t, 128)
+			writer := NewAES(passphrase, v.opt, WithRandomNonce())
+			reader := NewAES(passphrase, v.opt)
+			salt := writer.NewInt64Salt(42)
+
+			// when
+			ciphertext1, err := writer.Encrypt(plaintext, salt)
+			assert.NoError(t, err)
+
+			ciphertext2, err := writer.Encrypt(plaintext, salt)
+			assert.NoError(t, err)
+
+			rettext, err := reader.Decrypt(ciphertext1, salt)
+			assert.NoError(t, err)
+
+			_, errPassphrase := NewAES("wrong", v.opt).Decrypt(ciphertext1, salt)
+
+			tampered := append([]byte(nil), ciphertext1...)
+			binary.BigEndian.PutUint32(tampered[envelopeHeaderLen+1:], 2)
+			_, errTampered := reader.Decrypt(tampered, salt)
+
+			// then
+			assert.Equal(t, v.id, ciphertext1[envelopeHeaderLen])
+			assert.Equal(t, ciphertext1[:envelopeHeaderLen+passwordBlockLen], ciphertext2[:envelopeHeaderLen+passwordBlockLen])
+			assert.Equal(t, plaintext, rettext)
+			assert.Error(t, errPassphrase)
+			assert.Error(t, errTampered)
+		})
+	}
+}
+
+func TestPasswordKDFInvalidParams(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name string
+		opt  Option
+	}{
+		{
+			name: "Argon2idZeroTime",
+			opt:  WithArgon2id(0, 8*1024, 1),
+		},
+		{
+			name: "Argon2idTooMuchMemory",
+			opt:  WithArgon2id(1, maxArgon2Memory+1, 1),
+		},
+		{
+			name: "ScryptNotPowerOf2",
+			opt:  WithScrypt(1000, 8, 1),
+		},
+		{
+			name: "ScryptTooMuchMemory",
+			opt:  WithScrypt(1<<20, 8, 1),
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			c := NewAES("passphrase", v.opt)
+
+			// when
+			_, err := c.Encrypt([]byte("plaintext"), nil)
+
+			// then
+			assert.ErrorIs(t, err, ErrInvalidPasswordKDF)
+		})
+	}
+}
+
+func TestPasswordKDFHostileHeader(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name  string
+		opts  []Option
+		block string
+	}{
+		{
+			name:  "NotConfigured",
+			opts:  []Option{WithEnvelope()},
+			block: "02" + "01000000" + "00100000" + "00000001",
+		},
+		{
+			name:  "KDFMismatch",
+			opts:  []Option{WithArgon2id(1, 8*1024, 1)},
+			block: "02" + "00000400" + "00000008" + "00000001",
+		},
+		{
+			name:  "ScryptAboveConfigured",
+			opts:  []Option{WithScrypt(1024, 8, 1)},
+			block: "02" + "01000000" + "00100000" + "00000001",
+		},
+		{
+			name:  "Argon2idAboveConfigured",
+			opts:  []Option{WithArgon2id(1, 8*1024, 1)},
+			block: "01" + "00000040" + "00100000" + "00000001",
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			header := decodeHex(t, "0103020c00000000"+v.block)
+			ciphertext := append(header, make([]byte, passwordSaltLen+32)...)
+			c := NewAES("secret", v.opts...)
+
+			// when
+			_, err := c.Decrypt(ciphertext, nil)
+
+			// then
+			assert.ErrorIs(t, err, ErrInvalidPasswordKDF)
+		})
+	}
+}
+
+func TestPasswordKDFUnsupported(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name string
+		fn   func(c *AES) error
+	}{
+		{
+			name: "EncryptWriter",
+			fn: func(c *AES) error {
+				_, err := c.NewEncryptWriter(io.Discard, nil)
+				return err
+			},
+		},
+		{
+			name: "IDCodec",
+			fn: func(c *AES) error {
+				_, err := c.NewIDCodec("user")
+				return err
+			},
+		},
+		{
+			name: "FF1",
+			fn: func(c *AES) error {
+				_, err := c.NewFF1("users.phone", Digits)
+				return err
+			},
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			c := NewAES("passphrase", WithScrypt(1024, 8, 1))
+
+			// when
+			err := v.fn(c)
+
+			// then
+			assert.ErrorIs(t, err, ErrPasswordKDFUnsupported)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaltBuilderBuild(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name string
+		salt *SaltBuilder
+		want string
+	}{
+		{
+			name: "Empty",
+			salt: NewSaltBuilder(),
+			want: "",
+		},
+		{
+			name: "Int64",
+			salt: NewSaltBuilder().Int64(-2),
+			want: "0100000008" + "fffffffffffffffe",
+		},
+		{
+			name: "Int32",
+			salt: NewSaltBuilder().Int32(42),
+			want: "0200000004" + "0000002a",
+		},
+		{
+			name: "Uint64",
+			salt: NewSaltBuilder().Uint64(42),
+			want: "0300000008" + "000000000000002a",
+		},
+		{
+			name: "Uint32",
+			salt: NewSaltBuilder().Uint32(42),
+			want: "0400000004" + "0000002a",
+		},
+		{
+			name: "String",
+			salt: NewSaltBuilder().String("user"),
+			want: "0500000004" + "75736572",
+		},
+		{
+			name: "Bytes",
+			salt: NewSaltBuilder().Bytes([]byte{1, 2}),
+			want: "0600000002" + "0102",
+		},
+		{
+			name: "UUID",
+			salt: NewSaltBuilder().UUID([16]byte{0: 0xa1, 15: 0xff}),
+			want: "0700000010" + "a10000000000000000000000000000ff",
+		},
+		{
+			name: "Time",
+			salt: NewSaltBuilder().Time(time.Date(2026, 1, 1, 0, 0, 0, 5, time.FixedZone("KST", 9*60*60))),
+			want: "080000000c" + "0000000069553a70" + "00000005",
+		},
+		{
+			name: "TenantEntityID",
+			salt: NewSaltBuilder().String("t1").String("user").Int64(42),
+			want: "05000000027431" + "050000000475736572" + "0100000008000000000000002a",
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// when
+			got := v.salt.Build()
+
+			// then
+			assert.Equal(t, v.want, hex.EncodeToString(got))
+		})
+	}
+}
+
+func TestSaltBuilderUnambiguous(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name string
+		a, b *SaltBuilder
+	}{
+		{
+			name: "StringBoundary",
+			a:    NewSaltBuilder().String("ab").String("c"),
+			b:    NewSaltBuilder().String("a").String("bc"),
+		},
+		{
+			name: "StringAndBytes",
+			a:    NewSaltBuilder().String("ab"),
+			b:    NewSaltBuilder().Bytes([]byte("ab")),
+		},
+		{
+			name: "Int32AndUint32",
+			a:    NewSaltBuilder().Int32(42),
+			b:    NewSaltBuilder().Uint32(42),
+		},
+		{
+			name: "Int64AndTwoInt32",
+			a:    NewSaltBuilder().Int64(42),
+			b:    NewSaltBuilder().Int32(0).Int32(42),
+		},
+		{
+			name: "Order",
+			a:    NewSaltBuilder().String("t1").Int64(1),
+			b:    NewSaltBuilder().Int64(1).String("t1"),
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// then
+			assert.NotEqual(t, v.a.Build(), v.b.Build())
+		})
+	}
+}
+
+func TestSaltBuilderEncrypt(t *testing.T) {
+	// given
+	c := NewAES(newRandHex(t, 16))
+	salt := NewSaltBuilder().String("tenant-1").String("user").Int64(42).Build()
+	other := NewSaltBuilder().String("tenant-2").String("user").Int64(42).Build()
+
+	// when
+	ciphertext, err := c.Encrypt([]byte("hello"), salt)
+	assert.NoError(t, err)
+	got, err := c.Decrypt(ciphertext, NewSaltBuilder().String("tenant-1").String("user").Int64(42).Build())
+	_, otherErr := c.Decrypt(ciphertext, other)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hello"), got)
+	assert.Error(t, otherErr)
+}
+
+func TestNewInt32Salt(t *testing.T) {
+	// given
+	c := NewAES(newRandHex(t, 16))
+
+	// then
+	assert.Len(t, c.NewInt32Salt(42), 8)
+	assert.Equal(t, c.NewInt64Salt(42), c.NewInt32Salt(42))
+	assert.NotEqual(t, c.NewInt64Salt(-1), c.NewInt32Salt(-1))
+	assert.Equal(t, "ffffffff00000000", hex.EncodeToString(c.NewInt32Salt(-1)))
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	// ErrSecretClosed is returned when the secret is used after Close
+	ErrSecretClosed = errors.New("cipher: secret closed")
+
+	// ErrSecretNotFound is returned when the secret source is empty or missing
+	ErrSecretNotFound = errors.New("cipher: secret not found")
+)
+
+// Secret holds secret bytes that are redacted when printed, marshaled or logged,
+// and zeroed on Close. The zero value is an empty secret.
+type Secret struct {
+	b      []byte
+	closed atomic.Bool
+}
+
+var (
+	_ fmt.Stringer   = (*Secret)(nil)
+	_ fmt.Formatter  = (*Secret)(nil)
+	_ slog.LogValuer = (*Secret)(nil)
+)
+
+// NewSecret creates Secret, it takes ownership of b and zeroes it on Close
+func NewSecret(b []byte) *Secret {
+	return &Secret{b: b}
+}
+
+// SecretFromEnv creates Secret from environment variable name
+func SecretFromEnv(name string) (*Secret, error) {
+	v, ok := os.LookupEnv(name)
+	if !ok || v == "" {
+		return nil, fmt.Errorf("%w: env %s", ErrSecretNotFound, name)
+	}
+	return NewSecret([]byte(v)), nil
+}
+
+// SecretFromFile creates Secret from file (e.g. mounted kubernetes or docker secrets),
+// a trailing newline is trimmed
+func SecretFromFile(path string) (*Secret, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSecretNotFound, err)
+	}
+
+	trimmed := bytes.TrimRight(b, "\r\n")
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("%w: empty file %s", ErrSecretNotFound, path)
+	}
+
+	s := NewSecret(bytes.Clone(trimmed))
+	clear(b)
+	return s, nil
+}
+
+// LoadSecret creates Secret from source, "env:NAME" reads environment variable
+// and "file:PATH" reads file, for secret references in config files
+func LoadSecret(source string) (*Secret, error) {
+	switch kind, ref, _ := strings.Cut(source, ":"); kind {
+	case "env":
+		return SecretFromEnv(ref)
+	case "file":
+		return SecretFromFile(ref)
+	}
+	return nil, fmt.Errorf("%w: unknown source %q", ErrSecretNotFound, redactSource(source))
+}
+
+func redactSource(source string) string {
+	if kind, _, ok := strings.Cut(source, ":"); ok {
+		return kind + ":..."
+	}
+	return redacted
+}
+
+// Bytes returns secret bytes, it must not be modified nor retained after Close
+func (s *Secret) Bytes() ([]byte, error) {
+	if s.closed.Load() {
+		return nil, ErrSecretClosed
+	}
+	return s.b, nil
+}
+
+// Close zeroes secret bytes, it must not be called while the secret is in use
+func (s *Secret) Close() error {
+	if s.closed.Swap(true) {
+		return nil
+	}
+
+	clear(s.b)
+	s.b = nil
+	return nil
+}
+
+// String implements fmt.Stringer, it returns redacted text
+func (s *Secret) String() string {
+	return redacted
+}
+
+// Format implements fmt.Formatter, it writes redacted text for every verb
+func (s *Secret) Format(f fmt.State, _ rune) {
+	_, _ = f.Write([]byte(redacted))
+}
+
+// MarshalJSON implements json.Marshaler, it returns redacted text
+func (s *Secret) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + redacted + `"`), nil
+}
+
+// MarshalText implements encoding.TextMarshaler, it returns redacted text
+func (s *Secret) MarshalText() ([]byte, error) {
+	return []byte(redacted), nil
+}
+
+// LogValue implements slog.LogValuer, it returns redacted text
+func (s *Secret) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
@@ -0,0 +1,192 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretRedaction(t *testing.T) {
+	// given
+	s := NewSecret([]byte("top-secret"))
+	config := struct {
+		Name   string
+		Secret *Secret
+	}{
+		Name:   "db",
+		Secret: s,
+	}
+
+	// when
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("config", "secret", s)
+	js, err := json.Marshal(config)
+
+	// then
+	assert.NoError(t, err)
+	for _, out := range []string{
+		s.String(),
+		fmt.Sprint(s),
+		fmt.Sprintf("%v %+v %#v %s %q %x", s, config, config, s, s, s),
+		string(js),
+		buf.String(),
+	} {
+		assert.NotContains(t, out, "top-secret")
+		assert.Contains(t, out, "[REDACTED]")
+	}
+}
+
+func TestSecretClose(t *testing.T) {
+	// given
+	b := []byte("top-secret")
+	s := NewSecret(b)
+
+	// when
+	err := s.Close()
+	_, bytesErr := s.Bytes()
+
+	// then
+	assert.NoError(t, err)
+	assert.ErrorIs(t, bytesErr, ErrSecretClosed)
+	assert.Equal(t, make([]byte, len(b)), b)
+	assert.NoError(t, s.Close())
+}
+
+func TestLoadSecret(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	path := filepath.Join(dir, "secret")
+	assert.NoError(t, os.WriteFile(path, []byte("from-file\n"), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "empty"), []byte("\n"), 0o600))
+	t.Setenv("KEECON_TEST_SECRET", "from-env")
+
+	// dataset
+	dataset := []struct {
+		name   string
+		source string
+		want   string
+		err    error
+	}{
+		{
+			name:   "Env",
+			source: "env:KEECON_TEST_SECRET",
+			want:   "from-env",
+		},
+		{
+			name:   "File",
+			source: "file:" + path,
+			want:   "from-file",
+		},
+		{
+			name:   "MissingEnv",
+			source: "env:KEECON_TEST_SECRET_MISSING",
+			err:    ErrSecretNotFound,
+		},
+		{
+			name:   "MissingFile",
+			source: "file:" + filepath.Join(dir, "missing"),
+			err:    ErrSecretNotFound,
+		},
+		{
+			name:   "EmptyFile",
+			source: "file:" + filepath.Join(dir, "empty"),
+			err:    ErrSecretNotFound,
+		},
+		{
+			name:   "UnknownSource",
+			source: "top-secret",
+			err:    ErrSecretNotFound,
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// when
+			s, err := LoadSecret(v.source)
+
+			// then
+			if v.err != nil {
+				assert.ErrorIs(t, err, v.err)
+				assert.NotContains(t, err.Error(), "top-secret")
+				return
+			}
+			assert.NoError(t, err)
+			b, err := s.Bytes()
+			assert.NoError(t, err)
+			assert.Equal(t, v.want, string(b))
+		})
+	}
+}
+
+func TestNewAESFromSecret(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name string
+		opts []Option
+	}{
+		{
+			name: "Default",
+		},
+		{
+			name: "Envelope",
+			opts: []Option{WithEnvelope()},
+		},
+		{
+			name: "Cache",
+			opts: []Option{WithAEADCache(4)},
+		},
+		{
+			name: "Scrypt",
+			opts: []Option{WithScrypt(1<<10, 8, 1)},
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			secret := newRandHex(t, 16)
+			c := NewAESFromSecret(NewSecret([]byte(secret)), v.opts...)
+			salt := c.NewInt64Salt(42)
+			plaintext := []byte("hello world")
+
+			// when
+			ciphertext, err := c.Encrypt(plaintext, salt)
+			assert.NoError(t, err)
+			decrypted, err := NewAES(secret, v.opts...).Decrypt(ciphertext, salt)
+			assert.NoError(t, err)
+			assert.NoError(t, c.Close())
+			_, encryptErr := c.Encrypt(plaintext, salt)
+			_, decryptErr := c.Decrypt(ciphertext, salt)
+
+			// then
+			assert.Equal(t, plaintext, decrypted)
+			assert.ErrorIs(t, encryptErr, ErrSecretClosed)
+			assert.ErrorIs(t, decryptErr, ErrSecretClosed)
+		})
+	}
+}
+
+func TestAESCloseStream(t *testing.T) {
+	// given
+	c := NewAES(newRandHex(t, 16))
+	assert.NoError(t, c.Close())
+
+	// when
+	_, err := c.NewEncryptWriter(&bytes.Buffer{}, c.NewInt64Salt(42))
+
+	// then
+	assert.ErrorIs(t, err, ErrSecretClosed)
+}
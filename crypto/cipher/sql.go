@@ -0,0 +1,175 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrUnboundColumn is returned when encrypted column is used before Bind
+	ErrUnboundColumn = errors.New("cipher: encrypted column is not bound")
+
+	// ErrNonceReuse is returned when a salt may be reused for a different plaintext
+	// with AES deriving the nonce from the salt (e.g. updated values of a row)
+	ErrNonceReuse = errors.New("cipher: salt reuse requires random nonce or GCM-SIV")
+)
+
+// SaltFunc returns salt of the row (e.g. NewInt64Salt of the primary key).
+// It is called on Value and Scan, so it may read a field scanned before.
+type SaltFunc func() []byte
+
+// column binds encrypted column to AES, column name and row salt
+type column struct {
+	cipher *AES
+	name   string
+	salt   SaltFunc
+}
+
+// Bind configures AES, column name (e.g. "users.email") and salt of the row.
+// The name is mixed into the salt and authenticated as additional data,
+// so columns of a row sharing the salt do not share keys and nonces.
+// AES must be in random nonce mode or GCM-SIV, as updates re-encrypt with the same salt.
+func (c *column) Bind(cipher *AES, name string, salt SaltFunc) {
+	c.cipher = cipher
+	c.name = name
+	c.salt = salt
+}
+
+func (c *column) encrypt(plaintext []byte) (driver.Value, error) {
+	if c.cipher == nil {
+		return nil, ErrUnboundColumn
+	}
+	if c.cipher.ReusesNonce() {
+		return nil, ErrNonceReuse
+	}
+	return c.cipher.EncryptWithAAD(plaintext, c.saltBytes(), []byte(c.name))
+}
+
+func (c *column) decrypt(src any) ([]byte, error) {
+	if c.cipher == nil {
+		return nil, ErrUnboundColumn
+	}
+
+	var ciphertext []byte
+	switch v := src.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		ciphertext = v
+	case string:
+		ciphertext = []byte(v)
+	default:
+		return nil, fmt.Errorf("unsupported encrypted column type: %T", src)
+	}
+	return c.cipher.DecryptWithAAD(ciphertext, c.saltBytes(), []byte(c.name))
+}
+
+func (c *column) saltBytes() []byte {
+	b := NewSaltBuilder()
+	if c.salt != nil {
+		b.Bytes(c.salt())
+	}
+	return b.String(c.name).Build()
+}
+
+// EncryptedString is string column encrypted on Value and decrypted on Scan.
+// NULL is scanned as empty string.
+type EncryptedString struct {
+	column
+	String string
+}
+
+var (
+	_ driver.Valuer = EncryptedString{}
+	_ sql.Scanner   = (*EncryptedString)(nil)
+)
+
+// Value implements driver.Valuer, it returns ciphertext
+func (s EncryptedString) Value() (driver.Value, error) {
+	return s.encrypt([]byte(s.String))
+}
+
+// Scan implements sql.Scanner, it decrypts ciphertext
+func (s *EncryptedString) Scan(src any) error {
+	plaintext, err := s.decrypt(src)
+	if err != nil {
+		return err
+	}
+
+	s.String = string(plaintext)
+	return nil
+}
+
+// EncryptedBytes is []byte column encrypted on Value and decrypted on Scan.
+// NULL is scanned as nil.
+type EncryptedBytes struct {
+	column
+	Bytes []byte
+}
+
+var (
+	_ driver.Valuer = EncryptedBytes{}
+	_ sql.Scanner   = (*EncryptedBytes)(nil)
+)
+
+// Value implements driver.Valuer, it returns ciphertext
+func (b EncryptedBytes) Value() (driver.Value, error) {
+	return b.encrypt(b.Bytes)
+}
+
+// Scan implements sql.Scanner, it decrypts ciphertext
+func (b *EncryptedBytes) Scan(src any) error {
+	plaintext, err := b.decrypt(src)
+	if err != nil {
+		return err
+	}
+
+	b.Bytes = plaintext
+	return nil
+}
+
+// EncryptedJSON is JSON column of V encrypted on Value and decrypted on Scan.
+// NULL is scanned as zero value.
+type EncryptedJSON[T any] struct {
+	column
+	V T
+}
+
+var (
+	_ driver.Valuer = EncryptedJSON[any]{}
+	_ sql.Scanner   = (*EncryptedJSON[any])(nil)
+)
+
+// Value implements driver.Valuer, it returns ciphertext of JSON
+func (j EncryptedJSON[T]) Value() (driver.Value, error) {
+	plaintext, err := json.Marshal(j.V)
+	if err != nil {
+		return nil, fmt.Errorf("marshal encrypted json column: %w", err)
+	}
+	return j.encrypt(plaintext)
+}
+
+// Scan implements sql.Scanner, it decrypts ciphertext and unmarshals JSON
+func (j *EncryptedJSON[T]) Scan(src any) error {
+	plaintext, err := j.decrypt(src)
+	if err != nil {
+		return err
+	}
+
+	var v T
+	if plaintext != nil {
+		if err := json.Unmarshal(plaintext, &v); err != nil {
+			return fmt.Errorf("unmarshal encrypted json column: %w", err)
+		}
+	}
+
+	j.V = v
+	return nil
+}
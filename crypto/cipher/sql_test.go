@@ -0,0 +1,141 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testProfile struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+type testUser struct {
+	ID      int64
+	Email   EncryptedString
+	Token   EncryptedBytes
+	Profile EncryptedJSON[testProfile]
+}
+
+func newTestUser(c *AES) *testUser {
+	u := &testUser{}
+	salt := func() []byte { return c.NewInt64Salt(u.ID) }
+	u.Email.Bind(c, "users.email", salt)
+	u.Token.Bind(c, "users.token", salt)
+	u.Profile.Bind(c, "users.profile", salt)
+	return u
+}
+
+func TestEncryptedColumns(t *testing.T) {
+	// given
+	c := NewAES(newRandHex(t, 16), WithRandomNonce())
+	u := newTestUser(c)
+	u.ID = 42
+	u.Email.String = "user@example.com"
+	u.Token.Bytes = newRandBytes(t, 32)
+	u.Profile.V = testProfile{Name: "user", Age: 20}
+
+	// when
+	email, err := u.Email.Value()
+	assert.NoError(t, err)
+	token, err := u.Token.Value()
+	assert.NoError(t, err)
+	profile, err := u.Profile.Value()
+	assert.NoError(t, err)
+
+	scanned := newTestUser(c)
+	scanned.ID = 42 // scanned before the encrypted columns
+	assert.NoError(t, scanned.Email.Scan(email))
+	assert.NoError(t, scanned.Token.Scan(token))
+	assert.NoError(t, scanned.Profile.Scan(string(profile.([]byte))))
+
+	other := newTestUser(c)
+	other.ID = 43
+	errSalt := other.Email.Scan(email)
+
+	swapped := newTestUser(c)
+	swapped.ID = 42
+	errColumn := swapped.Token.Scan(email)
+
+	// then
+	assert.NotEqual(t, []byte(u.Email.String), email)
+	assert.Equal(t, u.Email.String, scanned.Email.String)
+	assert.Equal(t, u.Token.Bytes, scanned.Token.Bytes)
+	assert.Equal(t, u.Profile.V, scanned.Profile.V)
+	assert.Error(t, errSalt)
+	assert.Error(t, errColumn)
+}
+
+func TestEncryptedColumnsNonceReuse(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name string
+		opts []Option
+		err  error
+	}{
+		{
+			name: "DerivedNonce",
+			opts: nil,
+			err:  ErrNonceReuse,
+		},
+		{
+			name: "RandomNonce",
+			opts: []Option{WithRandomNonce()},
+		},
+		{
+			name: "AES256GCMSIV",
+			opts: []Option{WithAES256GCMSIV()},
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			u := newTestUser(NewAES(newRandHex(t, 16), v.opts...))
+			u.ID = 42
+			u.Email.String = "user@example.com"
+			u.Token.Bytes = []byte("user@example.com")
+
+			// when
+			email, errEmail := u.Email.Value()
+			token, errToken := u.Token.Value()
+
+			// then
+			assert.ErrorIs(t, errEmail, v.err)
+			assert.ErrorIs(t, errToken, v.err)
+			if v.err == nil {
+				assert.NotEqual(t, email, token)
+			}
+		})
+	}
+}
+
+func TestEncryptedColumnsNullAndUnbound(t *testing.T) {
+	// given
+	u := newTestUser(NewAES(newRandHex(t, 16), WithRandomNonce()))
+	u.Email.String = "user@example.com"
+	u.Profile.V = testProfile{Name: "user"}
+	var unbound EncryptedString
+
+	// when
+	errEmail := u.Email.Scan(nil)
+	errProfile := u.Profile.Scan(nil)
+	_, errValue := unbound.Value()
+	errScan := unbound.Scan([]byte("ciphertext"))
+	errType := u.Email.Scan(42)
+
+	// then
+	assert.NoError(t, errEmail)
+	assert.NoError(t, errProfile)
+	assert.Empty(t, u.Email.String)
+	assert.Empty(t, u.Profile.V)
+	assert.ErrorIs(t, errValue, ErrUnboundColumn)
+	assert.ErrorIs(t, errScan, ErrUnboundColumn)
+	assert.Error(t, errType)
+}
@@ -0,0 +1,273 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// stream header layout (big endian)
+//
+//	+---------+-----------+----------+----------+-------------+------------+
+//	| version | algorithm | nonceLen | reserved | segmentSize | streamSalt |
+//	| 1 byte  | 1 byte    | 1 byte   | 1 byte   | 4 bytes     | 16 bytes   |
+//	+---------+-----------+----------+----------+-------------+------------+
+//
+// The header is followed by segments of segmentSize plaintext sealed with
+// nonce = zero prefix || segment counter (4 bytes) || last segment flag (1 byte),
+// as the STREAM construction. The header is authenticated with every segment.
+const (
+	streamVersion1      byte = 1
+	streamSaltLen            = 16
+	streamHeaderLen          = 8 + streamSaltLen
+	streamNonceSuffix        = 5
+	defaultSegmentSize       = 64 * 1024
+	maxStreamSegmentLen      = 16 * 1024 * 1024
+)
+
+var (
+	// ErrInvalidStream is returned when stream header is malformed
+	ErrInvalidStream = errors.New("cipher: invalid stream")
+
+	// ErrStreamTruncated is returned when stream ends before the last segment
+	ErrStreamTruncated = errors.New("cipher: stream truncated")
+
+	// ErrSegmentAuth is returned when a segment is tampered, reordered or truncated
+	ErrSegmentAuth = errors.New("cipher: segment authentication failed")
+)
+
+// WithSegmentSize configures plaintext segment size of streaming encryption
+func WithSegmentSize(n int) Option {
+	return func(c *AES) {
+		c.segmentSize = n
+	}
+}
+
+// NewEncryptWriter returns a writer that encrypts and authenticates to w.
+// Data is sealed in segments, so memory use is bounded by the segment size.
+// Close must be called to write the last segment, it does not close w.
+// It returns ErrPasswordKDFUnsupported with WithArgon2id or WithScrypt.
+func (c *AES) NewEncryptWriter(w io.Writer, salt []byte) (io.WriteCloser, error) {
+	if c.nonceLen < 12 || 0xff < c.nonceLen {
+		return nil, fmt.Errorf("invalid stream nonce length: %d", c.nonceLen)
+	}
+	if c.segmentSize <= 0 || maxStreamSegmentLen < c.segmentSize {
+		return nil, fmt.Errorf("invalid segment size: %d", c.segmentSize)
+	}
+
+	header := make([]byte, streamHeaderLen)
+	header[0] = streamVersion1
+	header[1] = byte(c.alg)
+	header[2] = byte(c.nonceLen)
+	binary.BigEndian.PutUint32(header[4:], uint32(c.segmentSize))
+	if _, err := io.ReadFull(rand.Reader, header[8:]); err != nil {
+		return nil, fmt.Errorf("read random stream salt: %w", err)
+	}
+
+	aead, err := c.newStreamAEAD(header, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+	return &encryptWriter{
+		w:      w,
+		aead:   aead,
+		header: header,
+		nonce:  make([]byte, c.nonceLen),
+		buf:    make([]byte, 0, c.segmentSize),
+	}, nil
+}
+
+// NewDecryptReader returns a reader that decrypts and authenticates r.
+// The settings are read from the stream header.
+// Read returns an error if the stream is tampered, reordered or truncated.
+func (c *AES) NewDecryptReader(r io.Reader, salt []byte) (io.Reader, error) {
+	header := make([]byte, streamHeaderLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("%w: read header: %w", ErrInvalidStream, err)
+	}
+
+	nonceLen := int(header[2])
+	segmentSize := int(binary.BigEndian.Uint32(header[4:]))
+	if header[0] != streamVersion1 || nonceLen < 12 || header[3] != 0 ||
+		segmentSize <= 0 || maxStreamSegmentLen < segmentSize {
+		return nil, ErrInvalidStream
+	}
+
+	aead, err := c.newStreamAEAD(header, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &decryptReader{
+		r:      bufio.NewReader(r),
+		aead:   aead,
+		header: header,
+		nonce:  make([]byte, nonceLen),
+		buf:    make([]byte, segmentSize+aead.Overhead()),
+	}, nil
+}
+
+func (c *AES) newStreamAEAD(header, salt []byte) (cipher.AEAD, error) {
+	alg := Algorithm(header[1])
+	if alg.KeyLen() == 0 {
+		return nil, fmt.Errorf("%w: unknown algorithm: %s", ErrInvalidStream, alg)
+	}
+
+	if c.password != nil {
+		return nil, ErrPasswordKDFUnsupported
+	}
+
+	ikm, err := c.secret.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	streamSalt := append(slices.Clone(salt), header[8:]...)
+	key, err := c.newKey(aeadParams{ikm: ikm, alg: alg}, streamSalt)
+	if err != nil {
+		return nil, err
+	}
+	return newCipherAEAD(alg, key, int(header[2]))
+}
+
+type segmentNonce []byte
+
+func (n segmentNonce) set(counter uint32, last bool) []byte {
+	suffix := n[len(n)-streamNonceSuffix:]
+	binary.BigEndian.PutUint32(suffix, counter)
+	suffix[4] = 0
+	if last {
+		suffix[4] = 1
+	}
+	return n
+}
+
+type encryptWriter struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	header  []byte
+	nonce   segmentNonce
+	buf     []byte
+	out     []byte
+	counter uint32
+	closed  bool
+	err     error
+}
+
+func (e *encryptWriter) Write(p []byte) (n int, err error) {
+	if e.closed {
+		return 0, errors.New("write to closed encrypt writer")
+	}
+
+	for len(p) > 0 && e.err == nil {
+		if len(e.buf) == cap(e.buf) {
+			e.err = e.flush(false)
+			continue
+		}
+
+		copied := copy(e.buf[len(e.buf):cap(e.buf)], p)
+		e.buf = e.buf[:len(e.buf)+copied]
+		n += copied
+		p = p[copied:]
+	}
+	return n, e.err
+}
+
+func (e *encryptWriter) Close() error {
+	if e.closed {
+		return e.err
+	}
+
+	e.closed = true
+	if e.err == nil {
+		e.err = e.flush(true)
+	}
+	return e.err
+}
+
+func (e *encryptWriter) flush(last bool) error {
+	if e.counter == ^uint32(0) && !last {
+		return errors.New("stream segment counter overflow")
+	}
+
+	e.out = e.aead.Seal(e.out[:0], e.nonce.set(e.counter, last), e.buf, e.header)
+	if _, err := e.w.Write(e.out); err != nil {
+		return err
+	}
+
+	e.buf = e.buf[:0]
+	e.counter++
+	return nil
+}
+
+type decryptReader struct {
+	r       *bufio.Reader
+	aead    cipher.AEAD
+	header  []byte
+	nonce   segmentNonce
+	buf     []byte
+	plain   []byte
+	counter uint32
+	last    bool
+	err     error
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.plain) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+		if d.last {
+			return 0, io.EOF
+		}
+		d.err = d.next()
+	}
+
+	n := copy(p, d.plain)
+	d.plain = d.plain[n:]
+	return n, nil
+}
+
+func (d *decryptReader) next() error {
+	n, err := io.ReadFull(d.r, d.buf)
+	switch {
+	case err == io.EOF:
+		return ErrStreamTruncated
+	case err == io.ErrUnexpectedEOF:
+		d.last = true
+	case err != nil:
+		return err
+	default:
+		if _, err := d.r.Peek(1); err == io.EOF {
+			d.last = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if d.counter == ^uint32(0) && !d.last {
+		return ErrSegmentAuth
+	}
+
+	plain, err := d.aead.Open(d.buf[:0], d.nonce.set(d.counter, d.last), d.buf[:n], d.header)
+	if err != nil {
+		return fmt.Errorf("%w: segment %d", ErrSegmentAuth, d.counter)
+	}
+
+	d.plain = plain
+	d.counter++
+	return nil
+}
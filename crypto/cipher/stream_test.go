@@ -0,0 +1,133 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name      string
+		size      int
+		plainSize int
+	}{
+		{
+			name:      "Empty",
+			size:      16,
+			plainSize: 0,
+		},
+		{
+			name:      "Partial",
+			size:      16,
+			plainSize: 40,
+		},
+		{
+			name:      "Aligned",
+			size:      16,
+			plainSize: 64,
+		},
+		{
+			name:      "Default",
+			size:      defaultSegmentSize,
+			plainSize: 3*defaultSegmentSize + 1,
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			secret := newRandHex(t, 16)
+			plaintext := newRandBytes(t, v.plainSize)
+			c := NewAES(secret, WithSegmentSize(v.size))
+			salt := c.NewInt64Salt(42)
+
+			// when
+			var buf bytes.Buffer
+			w, err := c.NewEncryptWriter(&buf, salt)
+			assert.NoError(t, err)
+
+			_, err = io.Copy(w, bytes.NewReader(plaintext))
+			assert.NoError(t, err)
+			assert.NoError(t, w.Close())
+
+			r, err := NewAES(secret).NewDecryptReader(&buf, salt)
+			assert.NoError(t, err)
+
+			rettext, err := io.ReadAll(r)
+			assert.NoError(t, err)
+
+			// then
+			assert.Equal(t, len(plaintext), len(rettext))
+			assert.True(t, bytes.Equal(plaintext, rettext))
+		})
+	}
+}
+
+func TestStreamTampered(t *testing.T) {
+	// given
+	secret := newRandHex(t, 16)
+	plaintext := newRandBytes(t, 100)
+	c := NewAES(secret, WithSegmentSize(16))
+	salt := c.NewInt64Salt(42)
+
+	var buf bytes.Buffer
+	w, err := c.NewEncryptWriter(&buf, salt)
+	assert.NoError(t, err)
+	_, err = w.Write(plaintext)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+
+	ciphertext := buf.Bytes()
+	segLen := 16 + 16 // segment + gcm tag
+	readAll := func(b []byte) error {
+		r, err := c.NewDecryptReader(bytes.NewReader(b), salt)
+		if err != nil {
+			return err
+		}
+		_, err = io.ReadAll(r)
+		return err
+	}
+
+	// when
+	truncated := ciphertext[:streamHeaderLen+2*segLen]
+	errTruncated := readAll(truncated)
+
+	dropped := append([]byte(nil), ciphertext[:streamHeaderLen+segLen]...)
+	dropped = append(dropped, ciphertext[streamHeaderLen+2*segLen:]...)
+	errDropped := readAll(dropped)
+
+	swapped := append([]byte(nil), ciphertext...)
+	copy(swapped[streamHeaderLen:], ciphertext[streamHeaderLen+segLen:streamHeaderLen+2*segLen])
+	copy(swapped[streamHeaderLen+segLen:], ciphertext[streamHeaderLen:streamHeaderLen+segLen])
+	errSwapped := readAll(swapped)
+
+	flipped := append([]byte(nil), ciphertext...)
+	flipped[len(flipped)-1] ^= 1
+	errFlipped := readAll(flipped)
+
+	errSalt := func() error {
+		r, err := c.NewDecryptReader(bytes.NewReader(ciphertext), c.NewInt64Salt(43))
+		assert.NoError(t, err)
+		_, err = io.ReadAll(r)
+		return err
+	}()
+
+	// then
+	assert.NoError(t, readAll(ciphertext))
+	assert.ErrorIs(t, errTruncated, ErrSegmentAuth)
+	assert.ErrorIs(t, errDropped, ErrSegmentAuth)
+	assert.ErrorIs(t, errSwapped, ErrSegmentAuth)
+	assert.ErrorIs(t, errFlipped, ErrSegmentAuth)
+	assert.ErrorIs(t, errSalt, ErrSegmentAuth)
+	assert.ErrorIs(t, readAll(ciphertext[:streamHeaderLen]), ErrStreamTruncated)
+	assert.ErrorIs(t, readAll(ciphertext[:4]), ErrInvalidStream)
+}
@@ -0,0 +1,295 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// structTag is the field tag of EncryptStruct and DecryptStruct
+const structTag = "encrypt"
+
+// ErrInvalidStructField is returned when a tagged field cannot be encrypted
+var ErrInvalidStructField = errors.New("cipher: invalid encrypt field")
+
+// EncryptStruct encrypts fields tagged `encrypt:""` or `encrypt:"salt=ID"` in place.
+// v must be a pointer to struct. It walks nested structs, pointers, slices, arrays, maps and interfaces.
+// Tagged fields which cannot be updated in place (e.g. in map values or interfaces
+// holding structs, not pointers) return ErrInvalidStructField.
+//
+// Tagged string fields are replaced with ciphertext encoded as EncryptString, []byte fields
+// with ciphertext, pointers to them are followed and nil is skipped.
+// The salt is read from the named field of the same struct:
+// int64 and int use NewInt64Salt, int32 uses NewInt32Salt, string and []byte are used as is.
+// The salt field must not be tagged itself, it returns ErrInvalidStructField otherwise.
+// The field path (e.g. "Addresses.Street") is mixed into the salt and authenticated
+// as additional data, so fields sharing the salt do not share keys and nonces.
+// Fields without salt require random nonce mode or GCM-SIV, it returns ErrNonceReuse otherwise.
+func (c *AES) EncryptStruct(v any) error {
+	return c.walkStruct(v, true)
+}
+
+// DecryptStruct decrypts fields tagged by EncryptStruct in place.
+func (c *AES) DecryptStruct(v any) error {
+	return c.walkStruct(v, false)
+}
+
+func (c *AES) walkStruct(v any, encrypt bool) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("%w: %T is not a non-nil pointer", ErrInvalidStructField, v)
+	}
+
+	w := &structWalker{
+		cipher:  c,
+		encrypt: encrypt,
+		visited: make(map[uintptr]bool),
+	}
+	return w.walk(rv, "")
+}
+
+type structWalker struct {
+	cipher  *AES
+	encrypt bool
+	visited map[uintptr]bool
+}
+
+func (w *structWalker) walk(v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || w.visited[v.Pointer()] {
+			return nil
+		}
+		w.visited[v.Pointer()] = true
+		return w.walk(v.Elem(), path)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		// values in interfaces are not addressable, only pointers can be updated
+		elem := v.Elem()
+		if elem.Kind() == reflect.Pointer {
+			return w.walk(elem, path)
+		}
+		if hasStructTag(elem.Type(), make(map[reflect.Type]bool)) {
+			return fmt.Errorf("%w: %s: %s in interface is not addressable", ErrInvalidStructField, path, elem.Type())
+		}
+
+	case reflect.Map:
+		// map values are not addressable, only pointers can be updated
+		t := v.Type()
+		if hasStructTag(t.Key(), make(map[reflect.Type]bool)) {
+			return fmt.Errorf("%w: %s: map key %s is not addressable", ErrInvalidStructField, path, t.Key())
+		}
+		if k := t.Elem().Kind(); k != reflect.Pointer && k != reflect.Interface {
+			if hasStructTag(t.Elem(), make(map[reflect.Type]bool)) {
+				return fmt.Errorf("%w: %s: map value %s is not addressable", ErrInvalidStructField, path, t.Elem())
+			}
+			return nil
+		}
+
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := w.walk(iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key())); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if err := w.walk(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Struct:
+		return w.walkFields(v, path)
+	}
+	return nil
+}
+
+func (w *structWalker) walkFields(v reflect.Value, path string) error {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+
+		tag, ok := field.Tag.Lookup(structTag)
+		if !ok {
+			if err := w.walk(v.Field(i), fieldPath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		salt, err := structSalt(w.cipher, v, tag)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidStructField, fieldPath, err)
+		}
+		if salt == nil && w.encrypt && w.cipher.ReusesNonce() {
+			return fmt.Errorf("%s: %w", fieldPath, ErrNonceReuse)
+		}
+
+		// list indexes are not bound, elements may be reordered
+		name := structFieldName(fieldPath)
+		salt = NewSaltBuilder().Bytes(salt).String(name).Build()
+		aad := []byte(name)
+		if err := w.crypt(v.Field(i), salt, aad); err != nil {
+			return fmt.Errorf("%s: %w", fieldPath, err)
+		}
+	}
+	return nil
+}
+
+func (w *structWalker) crypt(v reflect.Value, salt, aad []byte) error {
+	switch {
+	case v.Kind() == reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return w.crypt(v.Elem(), salt, aad)
+
+	case v.Kind() == reflect.String:
+		if w.encrypt {
+			b, err := w.cipher.EncryptWithAAD([]byte(v.String()), salt, aad)
+			if err != nil {
+				return err
+			}
+			v.SetString(w.cipher.encoding.EncodeToString(b))
+			return nil
+		}
+
+		decoded, err := w.cipher.encoding.DecodeString(v.String())
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+		}
+		b, err := w.cipher.DecryptWithAAD(decoded, salt, aad)
+		if err != nil {
+			return err
+		}
+		v.SetString(string(b))
+		return nil
+
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		if v.IsNil() {
+			return nil
+		}
+
+		var (
+			b   []byte
+			err error
+		)
+		if w.encrypt {
+			b, err = w.cipher.EncryptWithAAD(v.Bytes(), salt, aad)
+		} else {
+			b, err = w.cipher.DecryptWithAAD(v.Bytes(), salt, aad)
+		}
+		if err != nil {
+			return err
+		}
+		v.SetBytes(b)
+		return nil
+	}
+	return fmt.Errorf("%w: unsupported type %s", ErrInvalidStructField, v.Type())
+}
+
+// structSalt returns salt of the tag options: salt=FieldName
+func structSalt(c *AES, v reflect.Value, tag string) ([]byte, error) {
+	var name string
+	for _, opt := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(opt), "=")
+		switch key {
+		case "":
+		case "salt":
+			name = value
+		default:
+			return nil, fmt.Errorf("unknown tag option: %s", key)
+		}
+	}
+	if name == "" {
+		return nil, nil
+	}
+
+	sf, ok := v.Type().FieldByName(name)
+	if !ok {
+		return nil, fmt.Errorf("salt field not found: %s", name)
+	}
+	if _, ok := sf.Tag.Lookup(structTag); ok {
+		return nil, fmt.Errorf("salt field is encrypted: %s", name)
+	}
+	field := v.FieldByIndex(sf.Index)
+
+	switch field.Kind() {
+	case reflect.Int64, reflect.Int:
+		return c.NewInt64Salt(field.Int()), nil
+	case reflect.Int32:
+		return c.NewInt32Salt(int32(field.Int())), nil
+	case reflect.String:
+		return []byte(field.String()), nil
+	case reflect.Slice:
+		if field.Type().Elem().Kind() == reflect.Uint8 {
+			return field.Bytes(), nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported salt field type: %s %s", name, field.Type())
+}
+
+// structFieldName returns field path without list indexes
+func structFieldName(path string) string {
+	var b strings.Builder
+	for {
+		i := strings.IndexByte(path, '[')
+		if i < 0 {
+			b.WriteString(path)
+			return b.String()
+		}
+		b.WriteString(path[:i])
+
+		j := strings.IndexByte(path[i:], ']')
+		if j < 0 {
+			return b.String()
+		}
+		path = path[i+j+1:]
+	}
+}
+
+// hasStructTag reports whether values of t may hold encrypt tagged fields
+func hasStructTag(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return hasStructTag(t.Elem(), seen)
+
+	case reflect.Map:
+		return hasStructTag(t.Key(), seen) || hasStructTag(t.Elem(), seen)
+
+	case reflect.Struct:
+		for i := range t.NumField() {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if _, ok := field.Tag.Lookup(structTag); ok || hasStructTag(field.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
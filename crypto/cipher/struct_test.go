@@ -0,0 +1,248 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cipher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testAddress struct {
+	ID     int32
+	Street string `encrypt:"salt=ID"`
+}
+
+type testAccount struct {
+	ID        int64
+	Name      string
+	Email     string  `encrypt:"salt=ID"`
+	Phone     *string `encrypt:"salt=ID"`
+	Memo      *string `encrypt:"salt=ID"`
+	Token     []byte  `encrypt:"salt=ID"`
+	Address   testAddress
+	Addresses []*testAddress
+	Parent    *testAccount
+}
+
+func TestEncryptStruct(t *testing.T) {
+	// given
+	c := NewAES(newRandHex(t, 16))
+	phone := "010-1234-5678"
+	token := newRandBytes(t, 16)
+	account := &testAccount{
+		ID:      42,
+		Name:    "user",
+		Email:   "user@example.com",
+		Phone:   &phone,
+		Token:   token,
+		Address: testAddress{ID: 1, Street: "street 1"},
+		Addresses: []*testAddress{
+			{ID: 2, Street: "street 2"},
+			nil,
+		},
+	}
+	account.Parent = account // cycle
+
+	// when
+	assert.NoError(t, c.EncryptStruct(account))
+	encrypted := *account
+	encryptedPhone := *account.Phone
+	encryptedStreet := account.Addresses[0].Street
+
+	assert.NoError(t, c.DecryptStruct(account))
+
+	// then
+	assert.Equal(t, "user", encrypted.Name)
+	assert.NotEqual(t, "user@example.com", encrypted.Email)
+	assert.NotEqual(t, "010-1234-5678", encryptedPhone)
+	assert.NotEqual(t, "street 2", encryptedStreet)
+
+	assert.Equal(t, "user", account.Name)
+	assert.Equal(t, "user@example.com", account.Email)
+	assert.Equal(t, "010-1234-5678", *account.Phone)
+	assert.Nil(t, account.Memo)
+	assert.Equal(t, token, account.Token)
+	assert.Equal(t, "street 1", account.Address.Street)
+	assert.Equal(t, "street 2", account.Addresses[0].Street)
+}
+
+func TestEncryptStructSalt(t *testing.T) {
+	// given
+	c := NewAES(newRandHex(t, 16))
+	account := &testAccount{ID: 42, Email: "user@example.com"}
+	assert.NoError(t, c.EncryptStruct(account))
+
+	// when
+	account.ID = 43
+	err := c.DecryptStruct(account)
+
+	// then
+	assert.Error(t, err)
+}
+
+func TestEncryptStructMapAndInterface(t *testing.T) {
+	// given
+	c := NewAES(newRandHex(t, 16))
+	value := &struct {
+		Labeled map[string]*testAddress
+		Address any
+		Names   map[string]string
+	}{
+		Labeled: map[string]*testAddress{"home": {ID: 1, Street: "street 1"}},
+		Address: &testAddress{ID: 2, Street: "street 2"},
+		Names:   map[string]string{"home": "name"},
+	}
+
+	// when
+	assert.NoError(t, c.EncryptStruct(value))
+	encrypted1 := value.Labeled["home"].Street
+	encrypted2 := value.Address.(*testAddress).Street
+
+	assert.NoError(t, c.DecryptStruct(value))
+
+	// then
+	assert.NotEqual(t, "street 1", encrypted1)
+	assert.NotEqual(t, "street 2", encrypted2)
+	assert.Equal(t, "street 1", value.Labeled["home"].Street)
+	assert.Equal(t, "street 2", value.Address.(*testAddress).Street)
+	assert.Equal(t, "name", value.Names["home"])
+}
+
+func TestEncryptStructSharedSalt(t *testing.T) {
+	// given
+	c := NewAES(newRandHex(t, 16))
+	account := &testAccount{ID: 42, Email: "user@example.com", Token: []byte("user@example.com")}
+	assert.NoError(t, c.EncryptStruct(account))
+	token, err := c.encoding.DecodeString(account.Email)
+	assert.NoError(t, err)
+
+	// when
+	swapped := &testAccount{ID: 42, Token: token, Email: c.encoding.EncodeToString(account.Token)}
+	errSwapped := c.DecryptStruct(swapped)
+
+	// then
+	assert.NotEqual(t, token, account.Token)
+	assert.Error(t, errSwapped)
+}
+
+func TestEncryptStructNoSalt(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name string
+		opts []Option
+		err  error
+	}{
+		{
+			name: "DerivedNonce",
+			opts: nil,
+			err:  ErrNonceReuse,
+		},
+		{
+			name: "RandomNonce",
+			opts: []Option{WithRandomNonce()},
+		},
+		{
+			name: "AES256GCMSIV",
+			opts: []Option{WithAES256GCMSIV()},
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			c := NewAES(newRandHex(t, 16), v.opts...)
+			value := &struct {
+				Token string `encrypt:""`
+			}{Token: "token"}
+
+			// when
+			err := c.EncryptStruct(value)
+
+			// then
+			assert.ErrorIs(t, err, v.err)
+		})
+	}
+}
+
+func TestEncryptStructInvalid(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name  string
+		value any
+	}{
+		{
+			name:  "NotPointer",
+			value: testAccount{},
+		},
+		{
+			name: "UnknownSaltField",
+			value: &struct {
+				Email string `encrypt:"salt=ID"`
+			}{},
+		},
+		{
+			name: "UnsupportedSaltType",
+			value: &struct {
+				ID    float64
+				Email string `encrypt:"salt=ID"`
+			}{},
+		},
+		{
+			name: "UnknownTagOption",
+			value: &struct {
+				Email string `encrypt:"hash=sha256"`
+			}{},
+		},
+		{
+			name: "UnsupportedFieldType",
+			value: &struct {
+				ID  int64
+				Age int `encrypt:"salt=ID"`
+			}{},
+		},
+		{
+			name: "EncryptedSaltField",
+			value: &struct {
+				ID    int64
+				Email string `encrypt:"salt=ID"`
+				Phone string `encrypt:"salt=Email"`
+			}{ID: 1, Email: "email", Phone: "phone"},
+		},
+		{
+			name: "MapValue",
+			value: &struct {
+				Labeled map[string]testAddress
+			}{Labeled: map[string]testAddress{"home": {ID: 1, Street: "street"}}},
+		},
+		{
+			name: "EmptyMapValue",
+			value: &struct {
+				Labeled map[string][]testAddress
+			}{},
+		},
+		{
+			name: "InterfaceValue",
+			value: &struct {
+				Address any
+			}{Address: testAddress{ID: 1, Street: "street"}},
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			c := NewAES(newRandHex(t, 16))
+
+			// when
+			err := c.EncryptStruct(v.value)
+
+			// then
+			assert.ErrorIs(t, err, ErrInvalidStructField)
+		})
+	}
+}
@@ -0,0 +1,329 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hpke implements Hybrid Public Key Encryption (RFC 9180) base mode
+// with DHKEM(X25519, HKDF-SHA256), HKDF-SHA256 and AES-GCM or ChaCha20-Poly1305.
+// A sender encrypts to recipient public keys without shared secrets.
+package hpke
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// AEAD is the AEAD identifier of RFC 9180 7.3
+type AEAD uint16
+
+// AEAD identifiers
+const (
+	AES128GCM        AEAD = 0x0001
+	AES256GCM        AEAD = 0x0002
+	ChaCha20Poly1305 AEAD = 0x0003
+)
+
+// KeyLen returns the key length (Nk)
+func (a AEAD) KeyLen() int {
+	switch a {
+	case AES128GCM:
+		return 16
+	case AES256GCM, ChaCha20Poly1305:
+		return 32
+	}
+	return 0
+}
+
+const (
+	nonceLen = 12
+	tagLen   = 16
+
+	modeBase byte = 0x00
+
+	multiVersion1  byte = 1
+	multiHeaderLen      = 5
+)
+
+var (
+	// ErrOpen is returned when ciphertext fails authentication or is not for the recipient
+	ErrOpen = errors.New("hpke: message authentication failed")
+
+	// ErrInvalidCiphertext is returned when ciphertext is malformed
+	ErrInvalidCiphertext = errors.New("hpke: invalid ciphertext")
+)
+
+// HPKE implements single-shot HPKE encryption to one or more recipients
+type HPKE struct {
+	aead AEAD
+	info []byte
+
+	// ephemeral returns the ephemeral key, it is replaced by tests
+	ephemeral func() (*PrivateKey, error)
+}
+
+// Option defines configure HPKE settings
+type Option func(*HPKE)
+
+// New creates HPKE
+func New(opts ...Option) *HPKE {
+	ret := &HPKE{
+		aead:      AES256GCM,
+		ephemeral: GenerateKey,
+	}
+
+	for _, o := range opts {
+		o(ret)
+	}
+	return ret
+}
+
+// WithAES128GCM configures AES-128-GCM AEAD
+func WithAES128GCM() Option {
+	return func(h *HPKE) {
+		h.aead = AES128GCM
+	}
+}
+
+// WithAES256GCM configures AES-256-GCM AEAD (default)
+func WithAES256GCM() Option {
+	return func(h *HPKE) {
+		h.aead = AES256GCM
+	}
+}
+
+// WithChaCha20Poly1305 configures ChaCha20-Poly1305 AEAD
+func WithChaCha20Poly1305() Option {
+	return func(h *HPKE) {
+		h.aead = ChaCha20Poly1305
+	}
+}
+
+// WithInfo configures application info, it binds ciphertexts to the context
+// (e.g. "billing/v1") and must be equal on both sides
+func WithInfo(info []byte) Option {
+	return func(h *HPKE) {
+		h.info = info
+	}
+}
+
+// Seal encrypts plaintext to pk, it returns the encapsulated key followed by the ciphertext
+func (h *HPKE) Seal(pk *PublicKey, plaintext, aad []byte) ([]byte, error) {
+	ctx, enc, err := h.setupSender(pk)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.seal(enc, plaintext, aad), nil
+}
+
+// Open decrypts ciphertext of Seal with sk
+func (h *HPKE) Open(sk *PrivateKey, ciphertext, aad []byte) ([]byte, error) {
+	if len(ciphertext) < encLen+tagLen {
+		return nil, ErrInvalidCiphertext
+	}
+
+	ctx, err := h.setupRecipient(ciphertext[:encLen], sk)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.open(ciphertext[encLen:], aad)
+}
+
+// multi-recipient ciphertext layout (big endian)
+//
+//	+---------+---------+---------+-------------------------------+------------+
+//	| version | aead    | count   | count * (enc || wrapped key)  | ciphertext |
+//	| 1 byte  | 2 bytes | 2 bytes | (32 + Nk + 16) bytes each     |            |
+//	+---------+---------+---------+-------------------------------+------------+
+//
+// A random data key encrypts the plaintext once, it is sealed to each recipient.
+// Recipient slots carry no key ids, OpenMulti tries each slot.
+// The header and the slots are authenticated as additional data of the ciphertext.
+
+// SealMulti encrypts plaintext to every recipient
+func (h *HPKE) SealMulti(recipients []*PublicKey, plaintext, aad []byte) ([]byte, error) {
+	if len(recipients) == 0 || 0xffff < len(recipients) {
+		return nil, fmt.Errorf("invalid recipients: %d", len(recipients))
+	}
+
+	dataKey := make([]byte, h.aead.KeyLen())
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, fmt.Errorf("read random data key: %w", err)
+	}
+	defer clear(dataKey)
+
+	out := make([]byte, multiHeaderLen, multiHeaderLen+len(recipients)*h.slotLen()+len(plaintext)+tagLen)
+	out[0] = multiVersion1
+	binary.BigEndian.PutUint16(out[1:], uint16(h.aead))
+	binary.BigEndian.PutUint16(out[3:], uint16(len(recipients)))
+
+	for _, pk := range recipients {
+		slot, err := h.Seal(pk, dataKey, out[:multiHeaderLen])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, slot...)
+	}
+
+	aead, err := newAEAD(h.aead, dataKey)
+	if err != nil {
+		return nil, err
+	}
+	// the data key encrypts only this message, a zero nonce is unique
+	nonce := make([]byte, nonceLen)
+	return aead.Seal(out, nonce, plaintext, slices.Concat(out, aad)), nil
+}
+
+// OpenMulti decrypts ciphertext of SealMulti with sk of one of the recipients
+func (h *HPKE) OpenMulti(sk *PrivateKey, ciphertext, aad []byte) ([]byte, error) {
+	if len(ciphertext) < multiHeaderLen {
+		return nil, ErrInvalidCiphertext
+	}
+	if ciphertext[0] != multiVersion1 || AEAD(binary.BigEndian.Uint16(ciphertext[1:])) != h.aead {
+		return nil, ErrInvalidCiphertext
+	}
+
+	count := int(binary.BigEndian.Uint16(ciphertext[3:]))
+	slotsEnd := multiHeaderLen + count*h.slotLen()
+	if count == 0 || len(ciphertext) < slotsEnd+tagLen {
+		return nil, ErrInvalidCiphertext
+	}
+
+	header := ciphertext[:multiHeaderLen]
+	for i := range count {
+		slot := ciphertext[multiHeaderLen+i*h.slotLen() : multiHeaderLen+(i+1)*h.slotLen()]
+		dataKey, err := h.Open(sk, slot, header)
+		if err != nil {
+			continue
+		}
+		defer clear(dataKey)
+
+		aead, err := newAEAD(h.aead, dataKey)
+		if err != nil {
+			return nil, err
+		}
+		nonce := make([]byte, nonceLen)
+		plaintext, err := aead.Open(nil, nonce, ciphertext[slotsEnd:], slices.Concat(ciphertext[:slotsEnd], aad))
+		if err != nil {
+			return nil, ErrOpen
+		}
+		return plaintext, nil
+	}
+	return nil, ErrOpen
+}
+
+func (h *HPKE) slotLen() int {
+	return encLen + h.aead.KeyLen() + tagLen
+}
+
+// context is the encryption context of RFC 9180 5.2
+type context struct {
+	aead      cipher.AEAD
+	baseNonce []byte
+	seq       uint64
+}
+
+func (h *HPKE) setupSender(pk *PublicKey) (*context, []byte, error) {
+	ephemeral, err := h.ephemeral()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sharedSecret, enc, err := encap(pk, ephemeral)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, err := h.keySchedule(sharedSecret)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ctx, enc, nil
+}
+
+func (h *HPKE) setupRecipient(enc []byte, sk *PrivateKey) (*context, error) {
+	sharedSecret, err := decap(enc, sk)
+	if err != nil {
+		return nil, ErrOpen
+	}
+	return h.keySchedule(sharedSecret)
+}
+
+// keySchedule implements KeySchedule of RFC 9180 5.1 in base mode
+func (h *HPKE) keySchedule(sharedSecret []byte) (*context, error) {
+	if h.aead.KeyLen() == 0 {
+		return nil, fmt.Errorf("unsupported aead: %#04x", uint16(h.aead))
+	}
+
+	suiteID := []byte("HPKE")
+	suiteID = binary.BigEndian.AppendUint16(suiteID, kemX25519HKDFSHA256)
+	suiteID = binary.BigEndian.AppendUint16(suiteID, kdfHKDFSHA256)
+	suiteID = binary.BigEndian.AppendUint16(suiteID, uint16(h.aead))
+
+	pskIDHash := labeledExtract(suiteID, nil, "psk_id_hash", nil)
+	infoHash := labeledExtract(suiteID, nil, "info_hash", h.info)
+	keyScheduleContext := slices.Concat([]byte{modeBase}, pskIDHash, infoHash)
+
+	secret := labeledExtract(suiteID, sharedSecret, "secret", nil)
+	key, err := labeledExpand(suiteID, secret, "key", keyScheduleContext, h.aead.KeyLen())
+	if err != nil {
+		return nil, err
+	}
+	baseNonce, err := labeledExpand(suiteID, secret, "base_nonce", keyScheduleContext, nonceLen)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(h.aead, key)
+	if err != nil {
+		return nil, err
+	}
+	return &context{aead: aead, baseNonce: baseNonce}, nil
+}
+
+func (c *context) nonce() []byte {
+	nonce := slices.Clone(c.baseNonce)
+	for i := range 8 {
+		nonce[nonceLen-1-i] ^= byte(c.seq >> (8 * i))
+	}
+	c.seq++
+	return nonce
+}
+
+func (c *context) seal(dst, plaintext, aad []byte) []byte {
+	return c.aead.Seal(dst, c.nonce(), plaintext, aad)
+}
+
+func (c *context) open(ciphertext, aad []byte) ([]byte, error) {
+	plaintext, err := c.aead.Open(nil, c.nonce(), ciphertext, aad)
+	if err != nil {
+		return nil, ErrOpen
+	}
+	return plaintext, nil
+}
+
+func newAEAD(id AEAD, key []byte) (cipher.AEAD, error) {
+	if id == ChaCha20Poly1305 {
+		aead, err := chacha20poly1305.New(key)
+		if err != nil {
+			return nil, fmt.Errorf("new chacha20poly1305: %w", err)
+		}
+		return aead, nil
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("new aes cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("new gcm: %w", err)
+	}
+	return aead, nil
+}
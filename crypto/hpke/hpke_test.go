@@ -0,0 +1,240 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hpke
+
+import (
+	"bytes"
+	"crypto/sha3"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// RFC 9180 A.1, A.2 and the AES-256-GCM base mode vectors, the encryptions
+// are accumulated into SHAKE128 as the Go standard library does
+func TestHPKEVectors(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name        string
+		opt         Option
+		info        string
+		ikmE        string
+		ikmR        string
+		skRm        string
+		pkRm        string
+		enc         string
+		encryptions string
+	}{
+		{
+			name:        "AES128GCM",
+			opt:         WithAES128GCM(),
+			info:        "4f6465206f6e2061204772656369616e2055726e",
+			ikmE:        "7268600d403fce431561aef583ee1613527cff655c1343f29812e66706df3234",
+			ikmR:        "6db9df30aa07dd42ee5e8181afdb977e538f5e1fec8a06223f33f7013e525037",
+			skRm:        "4612c550263fc8ad58375df3f557aac531d26850903e55a9f23f21d8534e8ac8",
+			pkRm:        "3948cfe0ad1ddb695d780e59077195da6c56506b027329794ab02bca80815c4d",
+			enc:         "37fda3567bdbd628e88668c3c8d7e97d1d1253b6d4ea6d44c150f741f1bf4431",
+			encryptions: "dcabb32ad8e8acea785275323395abd0",
+		},
+		{
+			name:        "AES256GCM",
+			opt:         WithAES256GCM(),
+			info:        "4f6465206f6e2061204772656369616e2055726e",
+			ikmE:        "2cd7c601cefb3d42a62b04b7a9041494c06c7843818e0ce28a8f704ae7ab20f9",
+			ikmR:        "dac33b0e9db1b59dbbea58d59a14e7b5896e9bdf98fad6891e99d1686492b9ee",
+			skRm:        "497b4502664cfea5d5af0b39934dac72242a74f8480451e1aee7d6a53320333d",
+			pkRm:        "430f4b9859665145a6b1ba274024487bd66f03a2dd577d7753c68d7d7d00c00c",
+			enc:         "6c93e09869df3402d7bf231bf540fadd35cd56be14f97178f0954db94b7fc256",
+			encryptions: "1702e73e1e71705faa8241022af1deea",
+		},
+		{
+			name:        "ChaCha20Poly1305",
+			opt:         WithChaCha20Poly1305(),
+			info:        "4f6465206f6e2061204772656369616e2055726e",
+			ikmE:        "909a9b35d3dc4713a5e72a4da274b55d3d3821a37e5d099e74a647db583a904b",
+			ikmR:        "1ac01f181fdf9f352797655161c58b75c656a6cc2716dcb66372da835542e1df",
+			skRm:        "8057991eef8f1f1af18f4a9491d16a1ce333f695d4db8e38da75975c4478e0fb",
+			pkRm:        "4310ee97d88cc1f088a5576c77ab0cf5c3ac797f3d95139c6c84b5429c59662a",
+			enc:         "1afa08d3dec047a643885163f1180476fa7ddb54c6a8029ea33f95796bf2ac4a",
+			encryptions: "225fb3d35da3bb25e4371bcee4273502",
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			h := New(v.opt, WithInfo(decodeHex(t, v.info)))
+			h.ephemeral = func() (*PrivateKey, error) {
+				return DeriveKey(decodeHex(t, v.ikmE))
+			}
+			skR, err := DeriveKey(decodeHex(t, v.ikmR))
+			assert.NoError(t, err)
+			pkR, err := ParsePublicKey(decodeHex(t, v.pkRm))
+			assert.NoError(t, err)
+
+			// when
+			sender, enc, err := h.setupSender(pkR)
+			assert.NoError(t, err)
+			recipient, err := h.setupRecipient(enc, skR)
+			assert.NoError(t, err)
+
+			source, sink := sha3.NewSHAKE128(), sha3.NewSHAKE128()
+			for range 1000 {
+				aad, plaintext := drawRandomInput(t, source), drawRandomInput(t, source)
+				ciphertext := sender.seal(nil, plaintext, aad)
+				_, _ = sink.Write(ciphertext)
+
+				got, err := recipient.open(ciphertext, aad)
+				assert.NoError(t, err)
+				assert.True(t, bytes.Equal(plaintext, got))
+			}
+			encryptions := make([]byte, 16)
+			_, _ = sink.Read(encryptions)
+
+			// then
+			assert.Equal(t, v.skRm, hex.EncodeToString(skR.Bytes()))
+			assert.Equal(t, v.pkRm, hex.EncodeToString(skR.PublicKey().Bytes()))
+			assert.Equal(t, v.enc, hex.EncodeToString(enc))
+			assert.Equal(t, v.encryptions, hex.EncodeToString(encryptions))
+		})
+	}
+}
+
+func TestHPKESeal(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name string
+		opts []Option
+	}{
+		{name: "Default"},
+		{name: "AES128GCM", opts: []Option{WithAES128GCM()}},
+		{name: "ChaCha20Poly1305", opts: []Option{WithChaCha20Poly1305()}},
+		{name: "Info", opts: []Option{WithInfo([]byte("billing/v1"))}},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			h := New(v.opts...)
+			sk, err := GenerateKey()
+			assert.NoError(t, err)
+			other, err := GenerateKey()
+			assert.NoError(t, err)
+			plaintext := []byte("hello world")
+			aad := []byte("order-1")
+
+			// when
+			ciphertext, err := h.Seal(sk.PublicKey(), plaintext, aad)
+			assert.NoError(t, err)
+			got, openErr := h.Open(sk, ciphertext, aad)
+			_, otherErr := h.Open(other, ciphertext, aad)
+			_, aadErr := h.Open(sk, ciphertext, []byte("order-2"))
+			_, infoErr := New(append(v.opts, WithInfo([]byte("other")))...).Open(sk, ciphertext, aad)
+
+			// then
+			assert.NoError(t, openErr)
+			assert.Equal(t, plaintext, got)
+			assert.ErrorIs(t, otherErr, ErrOpen)
+			assert.ErrorIs(t, aadErr, ErrOpen)
+			assert.ErrorIs(t, infoErr, ErrOpen)
+		})
+	}
+}
+
+func TestHPKESealMulti(t *testing.T) {
+	// given
+	h := New()
+	var recipients []*PublicKey
+	var keys []*PrivateKey
+	for range 3 {
+		sk, err := GenerateKey()
+		assert.NoError(t, err)
+		keys = append(keys, sk)
+		recipients = append(recipients, sk.PublicKey())
+	}
+	outsider, err := GenerateKey()
+	assert.NoError(t, err)
+	plaintext := []byte("hello world")
+
+	// when
+	ciphertext, err := h.SealMulti(recipients, plaintext, nil)
+	assert.NoError(t, err)
+	_, outsiderErr := h.OpenMulti(outsider, ciphertext, nil)
+	tampered := append([]byte(nil), ciphertext...)
+	tampered[len(tampered)-1] ^= 1
+	_, tamperedErr := h.OpenMulti(keys[0], tampered, nil)
+	_, truncatedErr := h.OpenMulti(keys[0], ciphertext[:10], nil)
+
+	// then
+	for _, sk := range keys {
+		got, err := h.OpenMulti(sk, ciphertext, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, plaintext, got)
+	}
+	assert.ErrorIs(t, outsiderErr, ErrOpen)
+	assert.ErrorIs(t, tamperedErr, ErrOpen)
+	assert.ErrorIs(t, truncatedErr, ErrInvalidCiphertext)
+
+	_, err = h.SealMulti(nil, plaintext, nil)
+	assert.Error(t, err)
+}
+
+func TestKeyMarshalText(t *testing.T) {
+	// given
+	sk, err := GenerateKey()
+	assert.NoError(t, err)
+	config := struct {
+		PrivateKey *PrivateKey `json:"private_key"`
+		PublicKey  *PublicKey  `json:"public_key"`
+	}{
+		PrivateKey: sk,
+		PublicKey:  sk.PublicKey(),
+	}
+
+	// when
+	b, err := json.Marshal(config)
+	assert.NoError(t, err)
+	var got struct {
+		PrivateKey *PrivateKey `json:"private_key"`
+		PublicKey  *PublicKey  `json:"public_key"`
+	}
+	err = json.Unmarshal(b, &got)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, sk.Bytes(), got.PrivateKey.Bytes())
+	assert.Equal(t, sk.PublicKey().Bytes(), got.PublicKey.Bytes())
+
+	_, err = ParsePublicKey([]byte("short"))
+	assert.Error(t, err)
+	_, err = DeriveKey([]byte("short"))
+	assert.Error(t, err)
+}
+
+func drawRandomInput(t *testing.T, r io.Reader) []byte {
+	t.Helper()
+
+	l := make([]byte, 1)
+	_, err := r.Read(l)
+	assert.NoError(t, err)
+
+	b := make([]byte, int(l[0]))
+	_, err = r.Read(b)
+	assert.NoError(t, err)
+	return b
+}
+
+func decodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	assert.NoError(t, err)
+	return b
+}
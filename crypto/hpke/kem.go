@@ -0,0 +1,191 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hpke
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"slices"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// DHKEM(X25519, HKDF-SHA256) of RFC 9180 7.1
+const (
+	kemX25519HKDFSHA256 uint16 = 0x0020
+	kdfHKDFSHA256       uint16 = 0x0001
+
+	// encLen is the length of the encapsulated key (Nenc)
+	encLen = 32
+
+	// secretLen is the length of the KEM shared secret (Nsecret)
+	secretLen = 32
+)
+
+var (
+	versionLabel = []byte("HPKE-v1")
+	kemSuiteID   = binary.BigEndian.AppendUint16([]byte("KEM"), kemX25519HKDFSHA256)
+)
+
+// PrivateKey is X25519 private key of a recipient
+type PrivateKey struct {
+	key *ecdh.PrivateKey
+}
+
+// PublicKey is X25519 public key of a recipient
+type PublicKey struct {
+	key *ecdh.PublicKey
+}
+
+// GenerateKey generates a random recipient key pair
+func GenerateKey() (*PrivateKey, error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate x25519 key: %w", err)
+	}
+	return &PrivateKey{key: key}, nil
+}
+
+// DeriveKey derives a recipient key pair from ikm (at least 32 bytes of entropy), RFC 9180 7.1.3
+func DeriveKey(ikm []byte) (*PrivateKey, error) {
+	if len(ikm) < secretLen {
+		return nil, fmt.Errorf("invalid ikm length: %d", len(ikm))
+	}
+
+	prk := labeledExtract(kemSuiteID, nil, "dkp_prk", ikm)
+	sk, err := labeledExpand(kemSuiteID, prk, "sk", nil, 32)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePrivateKey(sk)
+}
+
+// ParsePrivateKey parses 32 bytes private key
+func ParsePrivateKey(b []byte) (*PrivateKey, error) {
+	key, err := ecdh.X25519().NewPrivateKey(b)
+	if err != nil {
+		return nil, fmt.Errorf("parse private key: %w", err)
+	}
+	return &PrivateKey{key: key}, nil
+}
+
+// ParsePublicKey parses 32 bytes public key
+func ParsePublicKey(b []byte) (*PublicKey, error) {
+	key, err := ecdh.X25519().NewPublicKey(b)
+	if err != nil {
+		return nil, fmt.Errorf("parse public key: %w", err)
+	}
+	return &PublicKey{key: key}, nil
+}
+
+// PublicKey returns the public key
+func (k *PrivateKey) PublicKey() *PublicKey {
+	return &PublicKey{key: k.key.PublicKey()}
+}
+
+// Bytes returns 32 bytes private key
+func (k *PrivateKey) Bytes() []byte {
+	return k.key.Bytes()
+}
+
+// MarshalText implements encoding.TextMarshaler, it returns base64 of Bytes
+func (k *PrivateKey) MarshalText() ([]byte, error) {
+	return base64.StdEncoding.AppendEncode(nil, k.Bytes()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (k *PrivateKey) UnmarshalText(text []byte) error {
+	b, err := base64.StdEncoding.AppendDecode(nil, text)
+	if err != nil {
+		return fmt.Errorf("decode private key: %w", err)
+	}
+
+	parsed, err := ParsePrivateKey(b)
+	if err != nil {
+		return err
+	}
+	*k = *parsed
+	return nil
+}
+
+// Bytes returns 32 bytes public key
+func (k *PublicKey) Bytes() []byte {
+	return k.key.Bytes()
+}
+
+// MarshalText implements encoding.TextMarshaler, it returns base64 of Bytes
+func (k *PublicKey) MarshalText() ([]byte, error) {
+	return base64.StdEncoding.AppendEncode(nil, k.Bytes()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (k *PublicKey) UnmarshalText(text []byte) error {
+	b, err := base64.StdEncoding.AppendDecode(nil, text)
+	if err != nil {
+		return fmt.Errorf("decode public key: %w", err)
+	}
+
+	parsed, err := ParsePublicKey(b)
+	if err != nil {
+		return err
+	}
+	*k = *parsed
+	return nil
+}
+
+// encap returns the shared secret and the encapsulated key of ephemeral key to pkR
+func encap(pkR *PublicKey, ephemeral *PrivateKey) (sharedSecret, enc []byte, err error) {
+	dh, err := ephemeral.key.ECDH(pkR.key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("x25519: %w", err)
+	}
+
+	enc = ephemeral.key.PublicKey().Bytes()
+	sharedSecret, err = extractAndExpand(dh, slices.Concat(enc, pkR.Bytes()))
+	if err != nil {
+		return nil, nil, err
+	}
+	return sharedSecret, enc, nil
+}
+
+// decap returns the shared secret of the encapsulated key
+func decap(enc []byte, skR *PrivateKey) ([]byte, error) {
+	pkE, err := ecdh.X25519().NewPublicKey(enc)
+	if err != nil {
+		return nil, fmt.Errorf("parse encapsulated key: %w", err)
+	}
+
+	dh, err := skR.key.ECDH(pkE)
+	if err != nil {
+		return nil, fmt.Errorf("x25519: %w", err)
+	}
+	return extractAndExpand(dh, slices.Concat(enc, skR.key.PublicKey().Bytes()))
+}
+
+func extractAndExpand(dh, kemContext []byte) ([]byte, error) {
+	prk := labeledExtract(kemSuiteID, nil, "eae_prk", dh)
+	return labeledExpand(kemSuiteID, prk, "shared_secret", kemContext, secretLen)
+}
+
+// labeledExtract implements LabeledExtract of RFC 9180 4
+func labeledExtract(suiteID, salt []byte, label string, ikm []byte) []byte {
+	return hkdf.Extract(sha256.New, slices.Concat(versionLabel, suiteID, []byte(label), ikm), salt)
+}
+
+// labeledExpand implements LabeledExpand of RFC 9180 4
+func labeledExpand(suiteID, prk []byte, label string, info []byte, n int) ([]byte, error) {
+	labeledInfo := binary.BigEndian.AppendUint16(nil, uint16(n))
+	labeledInfo = slices.Concat(labeledInfo, versionLabel, suiteID, []byte(label), info)
+
+	out := make([]byte, n)
+	if _, err := hkdf.Expand(sha256.New, prk, labeledInfo).Read(out); err != nil {
+		return nil, fmt.Errorf("hkdf expand %s: %w", label, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,206 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package keytree derives independent subkeys of one master secret by derivation path
+// (tenant, version, child labels) and purpose (encryption, MAC, blind index, tokens)
+package keytree
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"slices"
+
+	"github.com/keecon/pkg-go/crypto/cipher"
+	"github.com/keecon/pkg-go/crypto/searchable"
+	"golang.org/x/crypto/hkdf"
+)
+
+// HKDF info label of the derivation, it is followed by the encoded path and purpose
+var treeLabel = []byte("keytree/v1")
+
+// purpose separates keys of a path, it is unexported so callers cannot
+// derive a key of one purpose as another
+type purpose string
+
+// purposes, do not change: the values are persisted in the derived keys
+const (
+	purposeEncryption purpose = "encryption"
+	purposeMAC        purpose = "mac"
+	purposeBlindIndex purpose = "blind-index"
+	purposeToken      purpose = "token"
+	purposeRaw        purpose = "raw"
+)
+
+// subkeyLen is the length of derived subkey secrets
+const subkeyLen = 32
+
+// Tree derives subkeys of the master secret
+type Tree struct {
+	master   *cipher.Secret
+	hkdfHash func() hash.Hash
+	hkdfInfo []byte
+}
+
+// Option defines configure Tree settings
+type Option func(*Tree)
+
+// New creates Tree of the master secret, Tree does not close it
+func New(master *cipher.Secret, opts ...Option) *Tree {
+	ret := &Tree{
+		master:   master,
+		hkdfHash: sha256.New, // recommends
+	}
+
+	for _, o := range opts {
+		o(ret)
+	}
+	return ret
+}
+
+// WithHKDFHash configures Key Derivation Function (HKDF)
+func WithHKDFHash(fn func() hash.Hash) Option {
+	return func(t *Tree) {
+		t.hkdfHash = fn
+	}
+}
+
+// WithHKDFInfo configures Key Derivation Function (HKDF) info, it prefixes every path
+func WithHKDFInfo(info []byte) Option {
+	return func(t *Tree) {
+		t.hkdfInfo = info
+	}
+}
+
+// Root returns the root path
+func (t *Tree) Root() Path {
+	return Path{tree: t}
+}
+
+// segment is a typed path segment
+type segment struct {
+	kind  string
+	value string
+	num   uint32
+}
+
+// Path is a derivation path, it is immutable and methods return child paths.
+// Keys of different paths or purposes are independent.
+type Path struct {
+	tree     *Tree
+	segments []segment
+}
+
+// Tenant returns child path of tenant id
+func (p Path) Tenant(id string) Path {
+	return p.child(segment{kind: "tenant", value: id})
+}
+
+// Version returns child path of key version, bump it to rotate subkeys
+func (p Path) Version(v uint32) Path {
+	return p.child(segment{kind: "version", num: v})
+}
+
+// Child returns child path of label (e.g. "users.email")
+func (p Path) Child(label string) Path {
+	return p.child(segment{kind: "label", value: label})
+}
+
+func (p Path) child(s segment) Path {
+	return Path{tree: p.tree, segments: append(slices.Clone(p.segments), s)}
+}
+
+// AES returns cipher.AES of the encryption subkey, the caller closes it
+func (p Path) AES(opts ...cipher.Option) (*cipher.AES, error) {
+	key, err := p.derive(purposeEncryption, subkeyLen)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewAESFromSecret(cipher.NewSecret(key), opts...), nil
+}
+
+// Signer returns HMAC Signer of the MAC subkey
+func (p Path) Signer(fn func() hash.Hash) (*Signer, error) {
+	key, err := p.derive(purposeMAC, fn().Size())
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{key: key, hash: fn}, nil
+}
+
+// Searchable returns searchable.Searchable of the blind index subkey, the caller closes it
+func (p Path) Searchable(opts ...searchable.Option) (*searchable.Searchable, error) {
+	key, err := p.derive(purposeBlindIndex, subkeyLen)
+	if err != nil {
+		return nil, err
+	}
+	return searchable.NewFromSecret(cipher.NewSecret(key), opts...), nil
+}
+
+// TokenSecret returns Secret of the token subkey (e.g. for token.Manager.Add), the caller closes it
+func (p Path) TokenSecret() (*cipher.Secret, error) {
+	key, err := p.derive(purposeToken, subkeyLen)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewSecret(key), nil
+}
+
+// Key returns n bytes raw key material for other uses, it is independent of the other purposes
+func (p Path) Key(n int) ([]byte, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("keytree: invalid key length: %d", n)
+	}
+	return p.derive(purposeRaw, n)
+}
+
+// derive expands HKDF of the master secret with info of the path and the purpose
+func (p Path) derive(pur purpose, n int) ([]byte, error) {
+	if p.tree == nil {
+		return nil, errors.New("keytree: path of nil tree")
+	}
+
+	ikm, err := p.tree.master.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := cipher.NewSaltBuilder()
+	for _, s := range p.segments {
+		info.String(s.kind)
+		if s.kind == "version" {
+			info.Uint32(s.num)
+		} else {
+			info.String(s.value)
+		}
+	}
+	info.String("purpose").String(string(pur))
+
+	kdf := hkdf.New(p.tree.hkdfHash, ikm, nil, slices.Concat(p.tree.hkdfInfo, treeLabel, info.Build()))
+	key := make([]byte, n)
+	if _, err := kdf.Read(key); err != nil {
+		return nil, fmt.Errorf("hkdf expand %s key: %w", pur, err)
+	}
+	return key, nil
+}
+
+// Signer signs and verifies messages with HMAC
+type Signer struct {
+	key  []byte
+	hash func() hash.Hash
+}
+
+// Sign returns HMAC of message
+func (s *Signer) Sign(message []byte) []byte {
+	mac := hmac.New(s.hash, s.key)
+	mac.Write(message)
+	return mac.Sum(nil)
+}
+
+// Verify returns whether sig is HMAC of message, it compares in constant time
+func (s *Signer) Verify(message, sig []byte) bool {
+	return hmac.Equal(s.Sign(message), sig)
+}
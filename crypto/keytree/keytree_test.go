@@ -0,0 +1,182 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package keytree
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"testing"
+
+	"github.com/keecon/pkg-go/crypto/cipher"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathKey(t *testing.T) {
+	// given
+	tree := New(cipher.NewSecret([]byte("master")))
+	base := tree.Root().Tenant("t1").Version(1)
+
+	// dataset
+	dataset := []struct {
+		name  string
+		path  Path
+		equal bool
+	}{
+		{
+			name:  "SamePath",
+			path:  New(cipher.NewSecret([]byte("master"))).Root().Tenant("t1").Version(1),
+			equal: true,
+		},
+		{
+			name: "OtherTenant",
+			path: tree.Root().Tenant("t2").Version(1),
+		},
+		{
+			name: "OtherVersion",
+			path: tree.Root().Tenant("t1").Version(2),
+		},
+		{
+			name: "Child",
+			path: base.Child("users.email"),
+		},
+		{
+			name: "SegmentOrder",
+			path: tree.Root().Version(1).Tenant("t1"),
+		},
+		{
+			name: "SegmentKind",
+			path: tree.Root().Child("t1").Version(1),
+		},
+		{
+			name: "HKDFInfo",
+			path: New(cipher.NewSecret([]byte("master")), WithHKDFInfo([]byte("app"))).Root().Tenant("t1").Version(1),
+		},
+		{
+			name: "HKDFHash",
+			path: New(cipher.NewSecret([]byte("master")), WithHKDFHash(sha512.New)).Root().Tenant("t1").Version(1),
+		},
+		{
+			name: "Master",
+			path: New(cipher.NewSecret([]byte("other"))).Root().Tenant("t1").Version(1),
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// when
+			want, err := base.Key(32)
+			assert.NoError(t, err)
+			got, err := v.path.Key(32)
+			assert.NoError(t, err)
+
+			// then
+			assert.Len(t, got, 32)
+			assert.Equal(t, v.equal, string(want) == string(got))
+		})
+	}
+}
+
+func TestPathPurpose(t *testing.T) {
+	// given
+	path := New(cipher.NewSecret([]byte("master"))).Root().Tenant("t1")
+
+	// when
+	raw, err := path.Key(32)
+	assert.NoError(t, err)
+	encryption, err := path.derive(purposeEncryption, 32)
+	assert.NoError(t, err)
+	mac, err := path.derive(purposeMAC, 32)
+	assert.NoError(t, err)
+	token, err := path.TokenSecret()
+	assert.NoError(t, err)
+	tokenKey, err := token.Bytes()
+	assert.NoError(t, err)
+
+	// then
+	keys := map[string]struct{}{
+		string(raw):        {},
+		string(encryption): {},
+		string(mac):        {},
+		string(tokenKey):   {},
+	}
+	assert.Len(t, keys, 4)
+}
+
+func TestPathAES(t *testing.T) {
+	// given
+	master := []byte("master")
+	writer, err := New(cipher.NewSecret(master)).Root().Tenant("t1").Version(1).AES(cipher.WithEnvelope())
+	assert.NoError(t, err)
+	reader, err := New(cipher.NewSecret(master)).Root().Tenant("t1").Version(1).AES(cipher.WithEnvelope())
+	assert.NoError(t, err)
+	other, err := New(cipher.NewSecret(master)).Root().Tenant("t2").Version(1).AES(cipher.WithEnvelope())
+	assert.NoError(t, err)
+	salt := writer.NewInt64Salt(42)
+
+	// when
+	ciphertext, err := writer.Encrypt([]byte("hello"), salt)
+	assert.NoError(t, err)
+	got, err := reader.Decrypt(ciphertext, salt)
+	_, otherErr := other.Decrypt(ciphertext, salt)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hello"), got)
+	assert.Error(t, otherErr)
+}
+
+func TestPathSigner(t *testing.T) {
+	// given
+	path := New(cipher.NewSecret([]byte("master"))).Root().Tenant("t1")
+	s, err := path.Signer(sha256.New)
+	assert.NoError(t, err)
+	other, err := path.Version(2).Signer(sha256.New)
+	assert.NoError(t, err)
+
+	// when
+	sig := s.Sign([]byte("hello"))
+
+	// then
+	assert.Len(t, sig, sha256.Size)
+	assert.True(t, s.Verify([]byte("hello"), sig))
+	assert.False(t, s.Verify([]byte("hullo"), sig))
+	assert.False(t, other.Verify([]byte("hello"), sig))
+}
+
+func TestPathSearchable(t *testing.T) {
+	// given
+	path := New(cipher.NewSecret([]byte("master"))).Root().Tenant("t1")
+	s, err := path.Searchable()
+	assert.NoError(t, err)
+	col, err := s.Column("users.email")
+	assert.NoError(t, err)
+	again, err := path.Searchable()
+	assert.NoError(t, err)
+	againCol, err := again.Column("users.email")
+	assert.NoError(t, err)
+
+	// then
+	assert.Equal(t, col.BlindIndex([]byte("a@example.com")), againCol.BlindIndex([]byte("a@example.com")))
+}
+
+func TestPathErrors(t *testing.T) {
+	// given
+	master := cipher.NewSecret([]byte("master"))
+	path := New(master).Root().Tenant("t1")
+	assert.NoError(t, master.Close())
+
+	// when
+	_, err := path.AES()
+	_, zeroErr := Path{}.Key(32)
+	_, lenErr := New(cipher.NewSecret([]byte("master"))).Root().Key(0)
+	_, negErr := New(cipher.NewSecret([]byte("master"))).Root().Key(-1)
+
+	// then
+	assert.ErrorIs(t, err, cipher.ErrSecretClosed)
+	assert.Error(t, zeroErr)
+	assert.Error(t, lenErr)
+	assert.Error(t, negErr)
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/keecon/pkg-go/crypto/cipher"
+)
+
+// envelope layout (big endian)
+//
+//	+---------+----------+-------+------------+---------+------------+
+//	| version | keyIDLen | keyID | wrappedLen | wrapped | ciphertext |
+//	| 1 byte  | 1 byte   |       | 2 bytes    |         |            |
+//	+---------+----------+-------+------------+---------+------------+
+//
+// The ciphertext is cipher.AES envelope sealed by the data key,
+// authenticated with the preceding header and caller additional data.
+const envelopeVersion1 byte = 1
+
+// ErrInvalidEnvelope is returned when envelope is malformed
+var ErrInvalidEnvelope = errors.New("kms: invalid envelope")
+
+// Encryptor implements envelope encryption.
+// Every Encrypt generates a data key, wrapped by the key manager and stored next to the ciphertext.
+type Encryptor struct {
+	km    KeyManager
+	keyID string
+	opts  []cipher.Option
+}
+
+// NewEncryptor creates Encryptor wrapping data keys by keyID,
+// opts configure cipher.AES sealing the data
+func NewEncryptor(km KeyManager, keyID string, opts ...cipher.Option) *Encryptor {
+	return &Encryptor{
+		km:    km,
+		keyID: keyID,
+		opts:  opts,
+	}
+}
+
+// Encrypt implements encrypt and authenticates plaintext and additional data with a new data key
+func (e *Encryptor) Encrypt(ctx context.Context, plaintext, aad []byte) ([]byte, error) {
+	if len(e.keyID) == 0 || 0xff < len(e.keyID) {
+		return nil, fmt.Errorf("invalid key id length: %d", len(e.keyID))
+	}
+
+	dek, wrapped, err := e.km.GenerateDataKey(ctx, e.keyID)
+	if err != nil {
+		return nil, fmt.Errorf("generate data key: %w", err)
+	}
+	defer clear(dek)
+	if 0xffff < len(wrapped) {
+		return nil, fmt.Errorf("invalid wrapped key length: %d", len(wrapped))
+	}
+
+	header := make([]byte, 0, 4+len(e.keyID)+len(wrapped))
+	header = append(header, envelopeVersion1, byte(len(e.keyID)))
+	header = append(header, e.keyID...)
+	header = binary.BigEndian.AppendUint16(header, uint16(len(wrapped)))
+	header = append(header, wrapped...)
+
+	c := e.dataCipher(dek)
+	defer c.Close()
+
+	ciphertext, err := c.EncryptWithAAD(plaintext, nil, slices.Concat(header, aad))
+	if err != nil {
+		return nil, err
+	}
+	return append(header, ciphertext...), nil
+}
+
+// Decrypt implements decrypt and authenticates ciphertext and additional data.
+// The data key is unwrapped by the key id stored in the envelope.
+func (e *Encryptor) Decrypt(ctx context.Context, ciphertext, aad []byte) ([]byte, error) {
+	keyID, wrapped, body, err := parseEnvelope(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	dek, err := e.km.Unwrap(ctx, keyID, wrapped)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(dek)
+
+	c := e.dataCipher(dek)
+	defer c.Close()
+
+	header := ciphertext[:len(ciphertext)-len(body)]
+	return c.DecryptWithAAD(body, nil, slices.Concat(header, aad))
+}
+
+// dataCipher returns AES of a copy of dek, it must be closed after use
+func (e *Encryptor) dataCipher(dek []byte) *cipher.AES {
+	opts := append(slices.Clone(e.opts), cipher.WithEnvelope(), cipher.WithRandomNonce())
+	return cipher.NewAESFromSecret(cipher.NewSecret(bytes.Clone(dek)), opts...)
+}
+
+func parseEnvelope(b []byte) (keyID string, wrapped, body []byte, err error) {
+	if len(b) < 2 || b[0] != envelopeVersion1 {
+		return "", nil, nil, ErrInvalidEnvelope
+	}
+
+	n := int(b[1])
+	b = b[2:]
+	if len(b) < n+2 {
+		return "", nil, nil, ErrInvalidEnvelope
+	}
+	keyID, b = string(b[:n]), b[n:]
+
+	n = int(binary.BigEndian.Uint16(b))
+	b = b[2:]
+	if len(b) < n {
+		return "", nil, nil, ErrInvalidEnvelope
+	}
+	return keyID, b[:n], b[n:], nil
+}
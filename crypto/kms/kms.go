@@ -0,0 +1,30 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package kms implements envelope encryption with data keys wrapped by a key manager
+package kms
+
+import (
+	"context"
+	"errors"
+)
+
+// DataKeySize is the data encryption key length in bytes
+const DataKeySize = 32
+
+// ErrKeyNotFound is returned when key encryption key is not in the keystore
+var ErrKeyNotFound = errors.New("kms: key not found")
+
+// KeyManager wraps data encryption keys (DEK) with key encryption keys (KEK).
+// The key encryption keys never leave the key manager, it can be a cloud KMS.
+type KeyManager interface {
+	// GenerateDataKey returns a new data key in plaintext and wrapped by keyID
+	GenerateDataKey(ctx context.Context, keyID string) (dek, wrapped []byte, err error)
+
+	// Wrap encrypts a data key by keyID
+	Wrap(ctx context.Context, keyID string, dek []byte) ([]byte, error)
+
+	// Unwrap decrypts a data key wrapped by keyID
+	Unwrap(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kms
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/keecon/pkg-go/crypto/cipher"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvelopeEncryptor(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name string
+		opts []cipher.Option
+	}{
+		{
+			name: "Default",
+		},
+		{
+			name: "XChaCha20Poly1305",
+			opts: []cipher.Option{cipher.WithXChaCha20Poly1305()},
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			ctx := context.Background()
+			store := newTestKeystore(t, "kek-1", "kek-2")
+			km := NewLocalKeyManager(store)
+			plaintext := []byte("plaintext")
+			aad := []byte("files/1")
+
+			// when
+			ciphertext1, err := NewEncryptor(km, "kek-1", v.opts...).Encrypt(ctx, plaintext, aad)
+			assert.NoError(t, err)
+
+			ciphertext2, err := NewEncryptor(km, "kek-2", v.opts...).Encrypt(ctx, plaintext, aad)
+			assert.NoError(t, err)
+
+			e := NewEncryptor(km, "kek-2", v.opts...)
+			rettext1, err := e.Decrypt(ctx, ciphertext1, aad)
+			assert.NoError(t, err)
+
+			rettext2, err := e.Decrypt(ctx, ciphertext2, aad)
+			assert.NoError(t, err)
+
+			_, errAAD := e.Decrypt(ctx, ciphertext1, []byte("files/2"))
+
+			// then
+			assert.Equal(t, plaintext, rettext1)
+			assert.Equal(t, plaintext, rettext2)
+			assert.Error(t, errAAD)
+		})
+	}
+}
+
+func TestEnvelopeEncryptorInvalid(t *testing.T) {
+	// given
+	ctx := context.Background()
+	km := NewLocalKeyManager(newTestKeystore(t, "kek-1"))
+	e := NewEncryptor(km, "kek-1")
+	ciphertext, err := e.Encrypt(ctx, []byte("plaintext"), nil)
+	assert.NoError(t, err)
+
+	// when
+	_, errUnknown := NewEncryptor(km, "kek-2").Encrypt(ctx, []byte("plaintext"), nil)
+	_, errShort := e.Decrypt(ctx, ciphertext[:4], nil)
+
+	tampered := append([]byte(nil), ciphertext...)
+	tampered[8] ^= 1
+	_, errTampered := e.Decrypt(ctx, tampered, nil)
+
+	// then
+	assert.ErrorIs(t, errUnknown, ErrKeyNotFound)
+	assert.ErrorIs(t, errShort, ErrInvalidEnvelope)
+	assert.Error(t, errTampered)
+}
+
+func TestFileKeystore(t *testing.T) {
+	// given
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "keystore.json")
+	store := newTestKeystore(t, "kek-1")
+	dek, wrapped, err := NewLocalKeyManager(store).GenerateDataKey(ctx, "kek-1")
+	assert.NoError(t, err)
+
+	// when
+	assert.NoError(t, SaveFileKeystore(path, store))
+	loaded, err := LoadFileKeystore(path)
+	assert.NoError(t, err)
+
+	unwrapped, err := NewLocalKeyManager(loaded).Unwrap(ctx, "kek-1", wrapped)
+	assert.NoError(t, err)
+
+	// then
+	assert.Len(t, dek, DataKeySize)
+	assert.Equal(t, dek, unwrapped)
+}
+
+func newTestKeystore(t *testing.T, ids ...string) *MemoryKeystore {
+	store := NewMemoryKeystore()
+	for _, id := range ids {
+		key, err := GenerateKey()
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		store.Set(id, key)
+	}
+	return store
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/keecon/pkg-go/crypto/cipher"
+)
+
+// Keystore provides key encryption keys by id
+type Keystore interface {
+	// Key returns the key encryption key of id
+	Key(id string) ([]byte, error)
+}
+
+// LocalKeyManager implements KeyManager with key encryption keys in a Keystore
+type LocalKeyManager struct {
+	store Keystore
+}
+
+var _ KeyManager = (*LocalKeyManager)(nil)
+
+// NewLocalKeyManager creates LocalKeyManager
+func NewLocalKeyManager(store Keystore) *LocalKeyManager {
+	return &LocalKeyManager{store: store}
+}
+
+// GenerateDataKey returns a new data key in plaintext and wrapped by keyID
+func (m *LocalKeyManager) GenerateDataKey(ctx context.Context, keyID string) (dek, wrapped []byte, err error) {
+	dek, err = GenerateKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wrapped, err = m.Wrap(ctx, keyID, dek)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dek, wrapped, nil
+}
+
+// Wrap encrypts a data key by keyID
+func (m *LocalKeyManager) Wrap(_ context.Context, keyID string, dek []byte) ([]byte, error) {
+	kek, err := m.kek(keyID)
+	if err != nil {
+		return nil, err
+	}
+	defer kek.Close()
+
+	return kek.EncryptWithAAD(dek, nil, []byte(keyID))
+}
+
+// Unwrap decrypts a data key wrapped by keyID
+func (m *LocalKeyManager) Unwrap(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	kek, err := m.kek(keyID)
+	if err != nil {
+		return nil, err
+	}
+	defer kek.Close()
+
+	dek, err := kek.DecryptWithAAD(wrapped, nil, []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("unwrap data key: %w", err)
+	}
+	return dek, nil
+}
+
+// kek returns AES of a copy of the stored key, it must be closed after use
+func (m *LocalKeyManager) kek(keyID string) (*cipher.AES, error) {
+	key, err := m.store.Key(keyID)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewAESFromSecret(cipher.NewSecret(bytes.Clone(key)), cipher.WithEnvelope(), cipher.WithRandomNonce()), nil
+}
+
+// GenerateKey returns a new random key, it can be a data key or key encryption key
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, DataKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("read random key: %w", err)
+	}
+	return key, nil
+}
+
+// MemoryKeystore implements in-memory Keystore
+type MemoryKeystore struct {
+	mu   sync.RWMutex
+	keys map[string][]byte
+}
+
+var _ Keystore = (*MemoryKeystore)(nil)
+
+// NewMemoryKeystore creates MemoryKeystore
+func NewMemoryKeystore() *MemoryKeystore {
+	return &MemoryKeystore{keys: make(map[string][]byte)}
+}
+
+// Set stores key of id
+func (s *MemoryKeystore) Set(id string, key []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys[id] = key
+}
+
+// Key returns the key encryption key of id
+func (s *MemoryKeystore) Key(id string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok := s.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, id)
+	}
+	return key, nil
+}
+
+// LoadFileKeystore reads keys from JSON file: {"key-id": "base64 key", ...}
+func LoadFileKeystore(path string) (*MemoryKeystore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read keystore file: %w", err)
+	}
+
+	keys := make(map[string][]byte)
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("parse keystore file: %w", err)
+	}
+	return &MemoryKeystore{keys: keys}, nil
+}
+
+// SaveFileKeystore writes keys to JSON file readable only by the owner
+func SaveFileKeystore(path string, s *MemoryKeystore) error {
+	s.mu.RLock()
+	data, err := json.Marshal(s.keys)
+	s.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("marshal keystore: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("write keystore file: %w", err)
+	}
+	return nil
+}
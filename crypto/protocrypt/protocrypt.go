@@ -0,0 +1,190 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package protocrypt encrypts protobuf message fields marked with (keecon.sensitive) = true
+package protocrypt
+
+import (
+	"fmt"
+
+	"github.com/keecon/pkg-go/crypto/cipher"
+	"github.com/keecon/pkg-go/proto/keecon"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// SaltFunc returns salt of the message
+type SaltFunc func(msg proto.Message) []byte
+
+// Crypter encrypts and decrypts sensitive fields of protobuf messages in place.
+// String fields hold encoded ciphertext, bytes fields hold raw ciphertext, unset fields are skipped.
+// The field full name is authenticated as additional data, so ciphertexts cannot be swapped between fields.
+//
+// The salt of a field is derived from the message salt and the field path with list indexes
+// and map keys (e.g. "addresses[0].street"), so fields and elements do not share keys and nonces.
+// Updated values are encrypted with the same salt, so Encrypt returns cipher.ErrNonceReuse
+// unless cipher.AES is configured with cipher.WithRandomNonce or GCM-SIV algorithm.
+type Crypter struct {
+	cipher   *cipher.AES
+	salt     SaltFunc
+	encoding cipher.Encoding
+}
+
+// Option defines configure Crypter settings
+type Option func(*Crypter)
+
+// New creates Crypter
+func New(c *cipher.AES, opts ...Option) *Crypter {
+	ret := &Crypter{
+		cipher:   c,
+		salt:     func(proto.Message) []byte { return nil },
+		encoding: cipher.StdBase64,
+	}
+
+	for _, o := range opts {
+		o(ret)
+	}
+	return ret
+}
+
+// WithSalt configures salt of the top-level message, nested messages use the same salt
+func WithSalt(fn SaltFunc) Option {
+	return func(c *Crypter) {
+		c.salt = fn
+	}
+}
+
+// WithEncoding configures text encoding of string fields, default is cipher.StdBase64
+func WithEncoding(e cipher.Encoding) Option {
+	return func(c *Crypter) {
+		c.encoding = e
+	}
+}
+
+// IsSensitive returns true if the field is marked with (keecon.sensitive) = true
+func IsSensitive(fd protoreflect.FieldDescriptor) bool {
+	opts := fd.Options()
+	if opts == nil {
+		return false
+	}
+	return proto.GetExtension(opts, keecon.E_Sensitive).(bool)
+}
+
+// Encrypt encrypts sensitive fields of msg and nested messages
+func (c *Crypter) Encrypt(msg proto.Message) error {
+	if c.cipher.ReusesNonce() {
+		return cipher.ErrNonceReuse
+	}
+	return c.walk(msg.ProtoReflect(), c.salt(msg), "", true)
+}
+
+// Decrypt decrypts sensitive fields of msg and nested messages
+func (c *Crypter) Decrypt(msg proto.Message) error {
+	return c.walk(msg.ProtoReflect(), c.salt(msg), "", false)
+}
+
+func (c *Crypter) walk(m protoreflect.Message, salt []byte, path string, encrypt bool) error {
+	var err error
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		fieldPath := string(fd.Name())
+		if path != "" {
+			fieldPath = path + "." + fieldPath
+		}
+
+		switch {
+		case IsSensitive(fd):
+			err = c.cryptField(m, fd, v, salt, fieldPath, encrypt)
+
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				return true
+			}
+			v.Map().Range(func(mk protoreflect.MapKey, mv protoreflect.Value) bool {
+				err = c.walk(mv.Message(), salt, fmt.Sprintf("%s[%s]", fieldPath, mk.String()), encrypt)
+				return err == nil
+			})
+
+		case fd.IsList() && fd.Message() != nil:
+			list := v.List()
+			for i := 0; i < list.Len() && err == nil; i++ {
+				err = c.walk(list.Get(i).Message(), salt, fmt.Sprintf("%s[%d]", fieldPath, i), encrypt)
+			}
+
+		case fd.Message() != nil:
+			err = c.walk(v.Message(), salt, fieldPath, encrypt)
+		}
+		return err == nil
+	})
+	return err
+}
+
+func (c *Crypter) cryptField(m protoreflect.Message, fd protoreflect.FieldDescriptor, v protoreflect.Value, salt []byte, path string, encrypt bool) error {
+	if fd.IsMap() || (fd.Kind() != protoreflect.StringKind && fd.Kind() != protoreflect.BytesKind) {
+		return fmt.Errorf("unsupported sensitive field: %s %s", fd.FullName(), fd.Kind())
+	}
+
+	if !fd.IsList() {
+		ret, err := c.crypt(fd, v, fieldSalt(salt, path), encrypt)
+		if err != nil {
+			return err
+		}
+		m.Set(fd, ret)
+		return nil
+	}
+
+	list := v.List()
+	for i := range list.Len() {
+		ret, err := c.crypt(fd, list.Get(i), fieldSalt(salt, fmt.Sprintf("%s[%d]", path, i)), encrypt)
+		if err != nil {
+			return err
+		}
+		list.Set(i, ret)
+	}
+	return nil
+}
+
+// fieldSalt returns salt of the field path mixed into the message salt
+func fieldSalt(salt []byte, path string) []byte {
+	return cipher.NewSaltBuilder().Bytes(salt).String(path).Build()
+}
+
+func (c *Crypter) crypt(fd protoreflect.FieldDescriptor, v protoreflect.Value, salt []byte, encrypt bool) (protoreflect.Value, error) {
+	aad := []byte(fd.FullName())
+
+	var in []byte
+	if fd.Kind() == protoreflect.StringKind {
+		if encrypt {
+			in = []byte(v.String())
+		} else {
+			decoded, err := c.encoding.DecodeString(v.String())
+			if err != nil {
+				return protoreflect.Value{}, fmt.Errorf("%s: %w: %w", fd.FullName(), cipher.ErrInvalidEncoding, err)
+			}
+			in = decoded
+		}
+	} else {
+		in = v.Bytes()
+	}
+
+	var (
+		out []byte
+		err error
+	)
+	if encrypt {
+		out, err = c.cipher.EncryptWithAAD(in, salt, aad)
+	} else {
+		out, err = c.cipher.DecryptWithAAD(in, salt, aad)
+	}
+	if err != nil {
+		return protoreflect.Value{}, fmt.Errorf("%s: %w", fd.FullName(), err)
+	}
+
+	switch {
+	case fd.Kind() == protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes(out), nil
+	case encrypt:
+		return protoreflect.ValueOfString(c.encoding.EncodeToString(out)), nil
+	}
+	return protoreflect.ValueOfString(string(out)), nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protocrypt
+
+import (
+	"testing"
+
+	"github.com/keecon/pkg-go/crypto/cipher"
+	"github.com/keecon/pkg-go/crypto/protocrypt/internal/testpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func newTestUser() *testpb.User {
+	return &testpb.User{
+		Id:     42,
+		Name:   "user",
+		Email:  "user@example.com",
+		Token:  []byte("token"),
+		Phones: []string{"010-1234-5678", "010-8765-4321"},
+		Address: &testpb.Address{
+			Street: "street 1",
+			City:   "city",
+		},
+		Addresses: []*testpb.Address{
+			{Street: "street 2"},
+		},
+		Labeled: map[string]*testpb.Address{
+			"home": {Street: "street 3"},
+		},
+	}
+}
+
+func TestCrypter(t *testing.T) {
+	// given
+	c := New(
+		cipher.NewAES("test-secret", cipher.WithRandomNonce()),
+		WithSalt(func(msg proto.Message) []byte {
+			return []byte(msg.(*testpb.User).GetName())
+		}),
+	)
+	user := newTestUser()
+
+	// when
+	assert.NoError(t, c.Encrypt(user))
+	encrypted := proto.Clone(user).(*testpb.User)
+
+	assert.NoError(t, c.Decrypt(user))
+
+	// then
+	assert.Equal(t, int64(42), encrypted.GetId())
+	assert.Equal(t, "user", encrypted.GetName())
+	assert.Equal(t, "city", encrypted.GetAddress().GetCity())
+	assert.NotEqual(t, "user@example.com", encrypted.GetEmail())
+	assert.NotEqual(t, []byte("token"), encrypted.GetToken())
+	assert.NotEqual(t, "010-1234-5678", encrypted.GetPhones()[0])
+	assert.NotEqual(t, "street 1", encrypted.GetAddress().GetStreet())
+	assert.NotEqual(t, "street 2", encrypted.GetAddresses()[0].GetStreet())
+	assert.NotEqual(t, "street 3", encrypted.GetLabeled()["home"].GetStreet())
+	assert.True(t, proto.Equal(newTestUser(), user))
+}
+
+func TestCrypterSwappedField(t *testing.T) {
+	// given
+	c := New(cipher.NewAES("test-secret", cipher.WithRandomNonce()))
+	user := newTestUser()
+	assert.NoError(t, c.Encrypt(user))
+
+	// when
+	user.Email = user.GetAddress().GetStreet()
+	err := c.Decrypt(user)
+
+	// then
+	assert.Error(t, err)
+}
+
+func TestCrypterFieldSalt(t *testing.T) {
+	// given
+	c := New(cipher.NewAES("test-secret", cipher.WithAES256GCMSIV()))
+	user := &testpb.User{
+		Email:  "user@example.com",
+		Phones: []string{"user@example.com", "user@example.com"},
+		Addresses: []*testpb.Address{
+			{Street: "street"},
+			{Street: "street"},
+		},
+	}
+
+	// when
+	assert.NoError(t, c.Encrypt(user))
+	encrypted := proto.Clone(user).(*testpb.User)
+
+	user.Phones[0], user.Phones[1] = user.Phones[1], user.Phones[0]
+	errSwapped := c.Decrypt(user)
+
+	// then
+	assert.NotEqual(t, encrypted.GetEmail(), encrypted.GetPhones()[0])
+	assert.NotEqual(t, encrypted.GetPhones()[0], encrypted.GetPhones()[1])
+	assert.NotEqual(t, encrypted.GetAddresses()[0].GetStreet(), encrypted.GetAddresses()[1].GetStreet())
+	assert.Error(t, errSwapped)
+}
+
+func TestCrypterNonceReuse(t *testing.T) {
+	// given
+	c := New(cipher.NewAES("test-secret"))
+	user := newTestUser()
+
+	// when
+	err := c.Encrypt(user)
+
+	// then
+	assert.ErrorIs(t, err, cipher.ErrNonceReuse)
+	assert.True(t, proto.Equal(newTestUser(), user))
+}
+
+func TestIsSensitive(t *testing.T) {
+	// given
+	fields := (&testpb.User{}).ProtoReflect().Descriptor().Fields()
+
+	// then
+	assert.False(t, IsSensitive(fields.ByName("name")))
+	assert.True(t, IsSensitive(fields.ByName("email")))
+	assert.True(t, IsSensitive(fields.ByName("token")))
+	assert.True(t, IsSensitive(fields.ByName("phones")))
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rekey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Checkpoint stores the last completed record key of Run
+type Checkpoint interface {
+	// Load returns the saved key, it returns empty when nothing is saved
+	Load(ctx context.Context) (string, error)
+
+	// Save saves the key
+	Save(ctx context.Context, key string) error
+}
+
+// MemoryCheckpoint implements Checkpoint in memory
+type MemoryCheckpoint struct {
+	mu  sync.Mutex
+	key string
+}
+
+var _ Checkpoint = (*MemoryCheckpoint)(nil)
+
+// Load implements Checkpoint
+func (c *MemoryCheckpoint) Load(_ context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.key, nil
+}
+
+// Save implements Checkpoint
+func (c *MemoryCheckpoint) Save(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.key = key
+	return nil
+}
+
+// FileCheckpoint implements Checkpoint in a file, it is replaced atomically on Save
+type FileCheckpoint struct {
+	path string
+}
+
+var _ Checkpoint = (*FileCheckpoint)(nil)
+
+// NewFileCheckpoint creates FileCheckpoint
+func NewFileCheckpoint(path string) *FileCheckpoint {
+	return &FileCheckpoint{path: path}
+}
+
+// Load implements Checkpoint
+func (c *FileCheckpoint) Load(_ context.Context) (string, error) {
+	b, err := os.ReadFile(c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("read checkpoint file: %w", err)
+	}
+	return string(b), nil
+}
+
+// Save implements Checkpoint
+func (c *FileCheckpoint) Save(_ context.Context, key string) error {
+	f, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return fmt.Errorf("create checkpoint file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.WriteString(key); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("write checkpoint file: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("sync checkpoint file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close checkpoint file: %w", err)
+	}
+	if err := os.Rename(f.Name(), c.path); err != nil {
+		return fmt.Errorf("rename checkpoint file: %w", err)
+	}
+	return nil
+}
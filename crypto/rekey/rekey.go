@@ -0,0 +1,316 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package rekey re-encrypts data from an old cipher configuration to a new one
+// (e.g. a rotated secret, another algorithm or HKDF info)
+package rekey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"sync"
+
+	"github.com/keecon/pkg-go/crypto/cipher"
+)
+
+// ErrTooManyFailures is returned when failed records exceed WithMaxFailures
+var ErrTooManyFailures = errors.New("rekey: too many failures")
+
+// Rekeyer decrypts with the old cipher and encrypts with the new one
+type Rekeyer struct {
+	from            *cipher.AES
+	to              *cipher.AES
+	workers         int
+	checkpoint      Checkpoint
+	checkpointEvery int
+	maxFailures     int
+}
+
+// Option defines configure Rekeyer settings
+type Option func(*Rekeyer)
+
+// New creates Rekeyer from the old cipher to the new cipher
+func New(from, to *cipher.AES, opts ...Option) *Rekeyer {
+	ret := &Rekeyer{
+		from:            from,
+		to:              to,
+		workers:         4,
+		checkpointEvery: 100,
+		maxFailures:     -1,
+	}
+
+	for _, o := range opts {
+		o(ret)
+	}
+	return ret
+}
+
+// WithWorkers configures the number of records re-encrypted in parallel
+func WithWorkers(n int) Option {
+	return func(r *Rekeyer) {
+		r.workers = max(n, 1)
+	}
+}
+
+// WithCheckpoint configures Checkpoint to resume Run
+func WithCheckpoint(cp Checkpoint) Option {
+	return func(r *Rekeyer) {
+		r.checkpoint = cp
+	}
+}
+
+// WithCheckpointEvery configures saving the checkpoint every n completed records
+func WithCheckpointEvery(n int) Option {
+	return func(r *Rekeyer) {
+		r.checkpointEvery = max(n, 1)
+	}
+}
+
+// WithMaxFailures configures aborting Run when failed records exceed n, negative is unlimited (default)
+func WithMaxFailures(n int) Option {
+	return func(r *Rekeyer) {
+		r.maxFailures = n
+	}
+}
+
+// Bytes re-encrypts ciphertext, salt and aad are kept
+func (r *Rekeyer) Bytes(ciphertext, salt, aad []byte) ([]byte, error) {
+	plaintext, err := r.from.DecryptWithAAD(ciphertext, salt, aad)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt: %w", err)
+	}
+	defer clear(plaintext)
+
+	ret, err := r.to.EncryptWithAAD(plaintext, salt, aad)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt: %w", err)
+	}
+	return ret, nil
+}
+
+// Stream re-encrypts the stream of src into dst, salt is kept
+func (r *Rekeyer) Stream(dst io.Writer, src io.Reader, salt []byte) error {
+	dr, err := r.from.NewDecryptReader(src, salt)
+	if err != nil {
+		return fmt.Errorf("decrypt: %w", err)
+	}
+
+	ew, err := r.to.NewEncryptWriter(dst, salt)
+	if err != nil {
+		return fmt.Errorf("encrypt: %w", err)
+	}
+
+	if _, err := io.Copy(ew, dr); err != nil {
+		return fmt.Errorf("copy: %w", err)
+	}
+	return ew.Close()
+}
+
+// Record is a ciphertext to re-encrypt, Key identifies it (e.g. a primary key)
+type Record struct {
+	Key        string
+	Ciphertext []byte
+	Salt       []byte
+	AAD        []byte
+}
+
+// Source returns records ordered by Key after the checkpoint key, after is empty at the first run
+type Source func(ctx context.Context, after string) iter.Seq2[Record, error]
+
+// Sink stores the new ciphertext of the record, it is called concurrently
+type Sink func(ctx context.Context, rec Record, ciphertext []byte) error
+
+// Failure is a record failed to re-encrypt or store
+type Failure struct {
+	Key string
+	Err error
+}
+
+// Report is the result of Run
+type Report struct {
+	// Processed is the number of records stored
+	Processed int
+
+	// Skipped is the number of records already encrypted with the new cipher (e.g. on resume)
+	Skipped int
+
+	// Failures are records failed to re-encrypt or store
+	Failures []Failure
+
+	// Checkpoint is the last key of which all the preceding records succeeded
+	Checkpoint string
+}
+
+type job struct {
+	seq int
+	rec Record
+}
+
+type result struct {
+	seq     int
+	key     string
+	skipped bool
+	err     error
+}
+
+// Run re-encrypts records of source and stores them to sink with bounded parallelism.
+// The checkpoint advances over the records succeeded in source order and stops at the first failure,
+// so Run resumes at the failed record. Records stored after it are skipped on resume,
+// as they are already encrypted with the new cipher. Failures are reported in Report.
+func (r *Rekeyer) Run(ctx context.Context, source Source, sink Sink) (*Report, error) {
+	var after string
+	if r.checkpoint != nil {
+		var err error
+		if after, err = r.checkpoint.Load(ctx); err != nil {
+			return nil, fmt.Errorf("load checkpoint: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	jobs := make(chan job)
+	results := make(chan result)
+
+	var wg sync.WaitGroup
+	for range r.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				skipped, err := r.record(ctx, job.rec, sink)
+				results <- result{seq: job.seq, key: job.rec.Key, skipped: skipped, err: err}
+			}
+		}()
+	}
+
+	report := &Report{Checkpoint: after}
+	collected := make(chan error, 1)
+	go func() {
+		collected <- r.collect(ctx, results, report, cancel)
+	}()
+
+	var sourceErr error
+	seq := 0
+	for rec, err := range source(ctx, after) {
+		if err != nil {
+			sourceErr = fmt.Errorf("source: %w", err)
+			break
+		}
+
+		select {
+		case jobs <- job{seq: seq, rec: rec}:
+			seq++
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	close(results)
+
+	if err := <-collected; err != nil {
+		return report, err
+	}
+	if sourceErr != nil {
+		return report, sourceErr
+	}
+	if err := context.Cause(ctx); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// record re-encrypts and stores rec, skipped is true if rec is already encrypted with the new cipher
+func (r *Rekeyer) record(ctx context.Context, rec Record, sink Sink) (skipped bool, err error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	ciphertext, err := r.Bytes(rec.Ciphertext, rec.Salt, rec.AAD)
+	if err != nil {
+		if r.rekeyed(rec) {
+			return true, nil
+		}
+		return false, err
+	}
+	if err := sink(ctx, rec, ciphertext); err != nil {
+		return false, fmt.Errorf("sink: %w", err)
+	}
+	return false, nil
+}
+
+// rekeyed reports whether rec is authenticated by the new cipher
+func (r *Rekeyer) rekeyed(rec Record) bool {
+	plaintext, err := r.to.DecryptWithAAD(rec.Ciphertext, rec.Salt, rec.AAD)
+	clear(plaintext)
+	return err == nil
+}
+
+// collect advances the checkpoint over results succeeded in sequence until the first failure
+func (r *Rekeyer) collect(ctx context.Context, results <-chan result, report *Report, cancel context.CancelCauseFunc) error {
+	pending := make(map[int]result)
+	next, unsaved := 0, 0
+	failed := false
+	var saveErr error
+
+	save := func() {
+		if r.checkpoint == nil || unsaved == 0 || saveErr != nil {
+			return
+		}
+		if err := r.checkpoint.Save(context.WithoutCancel(ctx), report.Checkpoint); err != nil {
+			saveErr = fmt.Errorf("save checkpoint: %w", err)
+			cancel(saveErr)
+			return
+		}
+		unsaved = 0
+	}
+
+	for res := range results {
+		if res.err != nil && ctx.Err() != nil && errors.Is(res.err, ctx.Err()) {
+			// canceled records are neither completed nor failed
+			continue
+		}
+
+		pending[res.seq] = res
+		for {
+			done, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+
+			switch {
+			case done.err != nil:
+				failed = true
+				report.Failures = append(report.Failures, Failure{Key: done.key, Err: done.err})
+				if 0 <= r.maxFailures && r.maxFailures < len(report.Failures) {
+					cancel(fmt.Errorf("%w: %d", ErrTooManyFailures, len(report.Failures)))
+				}
+				continue
+			case done.skipped:
+				report.Skipped++
+			default:
+				report.Processed++
+			}
+
+			if failed {
+				continue
+			}
+			report.Checkpoint = done.key
+			if unsaved++; r.checkpointEvery <= unsaved {
+				save()
+			}
+		}
+	}
+
+	save()
+	return saveErr
+}
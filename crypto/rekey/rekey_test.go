@@ -0,0 +1,229 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rekey
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/keecon/pkg-go/crypto/cipher"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRekeyerBytes(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name string
+		from *cipher.AES
+		to   *cipher.AES
+	}{
+		{
+			name: "Secret",
+			from: cipher.NewAES("old-secret"),
+			to:   cipher.NewAES("new-secret"),
+		},
+		{
+			name: "Algorithm",
+			from: cipher.NewAES("secret", cipher.WithAES128()),
+			to:   cipher.NewAES("secret", cipher.WithChaCha20Poly1305(), cipher.WithEnvelope()),
+		},
+		{
+			name: "HKDFInfo",
+			from: cipher.NewAES("secret"),
+			to:   cipher.NewAES("secret", cipher.WithHKDFInfo([]byte("v2"))),
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			r := New(v.from, v.to)
+			salt := v.from.NewInt64Salt(42)
+			plaintext := []byte("hello world")
+			ciphertext, err := v.from.EncryptWithAAD(plaintext, salt, []byte("aad"))
+			assert.NoError(t, err)
+
+			// when
+			rekeyed, err := r.Bytes(ciphertext, salt, []byte("aad"))
+			assert.NoError(t, err)
+			got, err := v.to.DecryptWithAAD(rekeyed, salt, []byte("aad"))
+
+			// then
+			assert.NoError(t, err)
+			assert.Equal(t, plaintext, got)
+		})
+	}
+}
+
+func TestRekeyerStream(t *testing.T) {
+	// given
+	from, to := cipher.NewAES("old-secret"), cipher.NewAES("new-secret", cipher.WithSegmentSize(1024))
+	r := New(from, to)
+	salt := from.NewInt64Salt(42)
+	plaintext := bytes.Repeat([]byte("hello world "), 1000)
+
+	var src bytes.Buffer
+	w, err := from.NewEncryptWriter(&src, salt)
+	assert.NoError(t, err)
+	_, err = w.Write(plaintext)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+
+	// when
+	var dst bytes.Buffer
+	err = r.Stream(&dst, &src, salt)
+	assert.NoError(t, err)
+	dr, err := to.NewDecryptReader(&dst, salt)
+	assert.NoError(t, err)
+	got, err := io.ReadAll(dr)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, plaintext, got)
+}
+
+type table struct {
+	mu   sync.Mutex
+	rows map[string][]byte
+	keys []string
+}
+
+func newTable(t *testing.T, c *cipher.AES, n int) *table {
+	t.Helper()
+
+	tb := &table{rows: make(map[string][]byte)}
+	for i := range n {
+		key := fmt.Sprintf("%04d", i)
+		ciphertext, err := c.Encrypt([]byte("row-"+key), []byte(key))
+		assert.NoError(t, err)
+		tb.rows[key] = ciphertext
+		tb.keys = append(tb.keys, key)
+	}
+	return tb
+}
+
+func (tb *table) source(ctx context.Context, after string) iter.Seq2[Record, error] {
+	return func(yield func(Record, error) bool) {
+		for _, key := range tb.keys {
+			if key <= after {
+				continue
+			}
+
+			tb.mu.Lock()
+			rec := Record{Key: key, Ciphertext: tb.rows[key], Salt: []byte(key)}
+			tb.mu.Unlock()
+			if !yield(rec, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (tb *table) sink(ctx context.Context, rec Record, ciphertext []byte) error {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.rows[rec.Key] = ciphertext
+	return nil
+}
+
+func TestRekeyerRun(t *testing.T) {
+	// given
+	from, to := cipher.NewAES("old-secret"), cipher.NewAES("new-secret")
+	tb := newTable(t, from, 250)
+	tb.rows["0100"] = []byte("corrupted")
+	cp := NewFileCheckpoint(filepath.Join(t.TempDir(), "checkpoint"))
+	r := New(from, to, WithWorkers(8), WithCheckpoint(cp), WithCheckpointEvery(10))
+
+	// when
+	report, err := r.Run(context.Background(), tb.source, tb.sink)
+	saved, loadErr := cp.Load(context.Background())
+
+	tb.rows["0100"], _ = from.Encrypt([]byte("row-0100"), []byte("0100"))
+	resumed, resumeErr := r.Run(context.Background(), tb.source, tb.sink)
+
+	// then
+	assert.NoError(t, err)
+	assert.NoError(t, loadErr)
+	assert.Equal(t, 249, report.Processed)
+	assert.Len(t, report.Failures, 1)
+	assert.Equal(t, "0100", report.Failures[0].Key)
+	assert.Error(t, report.Failures[0].Err)
+	assert.Equal(t, "0099", report.Checkpoint)
+	assert.Equal(t, "0099", saved)
+
+	assert.NoError(t, resumeErr)
+	assert.Equal(t, 1, resumed.Processed)
+	assert.Equal(t, 149, resumed.Skipped)
+	assert.Empty(t, resumed.Failures)
+	assert.Equal(t, "0249", resumed.Checkpoint)
+	for _, key := range tb.keys {
+		got, err := to.Decrypt(tb.rows[key], []byte(key))
+		assert.NoError(t, err)
+		assert.Equal(t, "row-"+key, string(got))
+	}
+}
+
+func TestRekeyerResume(t *testing.T) {
+	// given
+	from, to := cipher.NewAES("old-secret"), cipher.NewAES("new-secret")
+	tb := newTable(t, from, 100)
+	cp := &MemoryCheckpoint{}
+	errSink := errors.New("sink unavailable")
+	calls := 0
+	var mu sync.Mutex
+	failing := func(ctx context.Context, rec Record, ciphertext []byte) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if calls++; 30 < calls {
+			return errSink
+		}
+		return tb.sink(ctx, rec, ciphertext)
+	}
+	r := New(from, to, WithWorkers(1), WithCheckpoint(cp), WithCheckpointEvery(1), WithMaxFailures(0))
+
+	// when
+	report, err := r.Run(context.Background(), tb.source, failing)
+	resumed, resumeErr := r.Run(context.Background(), tb.source, tb.sink)
+
+	// then
+	assert.ErrorIs(t, err, ErrTooManyFailures)
+	assert.Equal(t, 30, report.Processed)
+	assert.NotEmpty(t, report.Failures)
+	assert.Equal(t, "0030", report.Failures[0].Key)
+	assert.ErrorIs(t, report.Failures[0].Err, errSink)
+	assert.Equal(t, "0029", report.Checkpoint)
+	assert.NoError(t, resumeErr)
+	assert.Equal(t, 70, resumed.Processed+resumed.Skipped)
+	assert.Empty(t, resumed.Failures)
+	assert.Equal(t, "0099", resumed.Checkpoint)
+}
+
+func TestRekeyerRunSourceError(t *testing.T) {
+	// given
+	from, to := cipher.NewAES("old-secret"), cipher.NewAES("new-secret")
+	errSource := errors.New("query failed")
+	source := func(ctx context.Context, after string) iter.Seq2[Record, error] {
+		return func(yield func(Record, error) bool) {
+			yield(Record{}, errSource)
+		}
+	}
+
+	// when
+	_, err := New(from, to).Run(context.Background(), source, nil)
+
+	// then
+	assert.ErrorIs(t, err, errSource)
+	assert.True(t, strings.HasPrefix(err.Error(), "source:"))
+}
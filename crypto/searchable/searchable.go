@@ -0,0 +1,141 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package searchable implements deterministic encryption and blind indexes for encrypted columns
+package searchable
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"slices"
+
+	"github.com/keecon/pkg-go/crypto/cipher"
+	"golang.org/x/crypto/hkdf"
+)
+
+// HKDF info labels separate encryption keys from index keys
+var (
+	encryptionLabel = []byte("searchable/deterministic")
+	indexLabel      = []byte("searchable/blind-index")
+)
+
+// Searchable derives column encryptors from a secret
+type Searchable struct {
+	secret   *cipher.Secret
+	hkdfHash func() hash.Hash
+	hkdfInfo []byte
+	indexLen int
+}
+
+// Option defines configure Searchable settings
+type Option func(*Searchable)
+
+// New creates Searchable
+func New(secret string, opts ...Option) *Searchable {
+	return NewFromSecret(cipher.NewSecret([]byte(secret)), opts...)
+}
+
+// NewFromSecret creates Searchable from Secret, Close zeroes it
+func NewFromSecret(secret *cipher.Secret, opts ...Option) *Searchable {
+	ret := &Searchable{
+		secret:   secret,
+		hkdfHash: sha256.New, // recommends
+		indexLen: 8,
+	}
+
+	for _, o := range opts {
+		o(ret)
+	}
+	return ret
+}
+
+// WithHKDFHash configures Key Derivation Function (HKDF)
+func WithHKDFHash(fn func() hash.Hash) Option {
+	return func(s *Searchable) {
+		s.hkdfHash = fn
+	}
+}
+
+// WithHKDFInfo configures Key Derivation Function (HKDF) info
+func WithHKDFInfo(info []byte) Option {
+	return func(s *Searchable) {
+		s.hkdfInfo = info
+	}
+}
+
+// WithIndexLength configures blind index length in bytes.
+// Shorter indexes leak less about the plaintext but match more false positives.
+func WithIndexLength(n int) Option {
+	return func(s *Searchable) {
+		s.indexLen = n
+	}
+}
+
+// Close zeroes the secret, Searchable and its columns must not be used after Close
+func (s *Searchable) Close() error {
+	return s.secret.Close()
+}
+
+// Column returns Column for the name (e.g. "users.email").
+// Keys are derived per column, so equal plaintexts in different columns do not match.
+func (s *Searchable) Column(name string) (*Column, error) {
+	size := s.hkdfHash().Size()
+	if s.indexLen < 4 || size < s.indexLen {
+		return nil, fmt.Errorf("invalid index length: %d", s.indexLen)
+	}
+
+	ikm, err := s.secret.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	indexKey := make([]byte, size)
+	kdf := hkdf.New(s.hkdfHash, ikm, []byte(name), slices.Concat(s.hkdfInfo, indexLabel))
+	if _, err := kdf.Read(indexKey); err != nil {
+		return nil, fmt.Errorf("hkdf expand index key: %w", err)
+	}
+
+	return &Column{
+		cipher: cipher.NewAESFromSecret(s.secret,
+			cipher.WithAES256GCMSIV(),
+			cipher.WithHKDFHash(s.hkdfHash),
+			cipher.WithHKDFInfo(slices.Concat(s.hkdfInfo, encryptionLabel)),
+		),
+		salt:     []byte(name),
+		hkdfHash: s.hkdfHash,
+		indexKey: indexKey,
+		indexLen: s.indexLen,
+	}, nil
+}
+
+// Column implements deterministic encryption and blind index of a column
+type Column struct {
+	cipher   *cipher.AES
+	salt     []byte
+	hkdfHash func() hash.Hash
+	indexKey []byte
+	indexLen int
+}
+
+// Encrypt implements deterministic encrypt and authenticates plaintext.
+// Equal plaintexts produce equal ciphertexts, so the column supports equality match.
+// It uses AES-GCM-SIV, then it reveals nothing but equality.
+func (c *Column) Encrypt(plaintext []byte) ([]byte, error) {
+	return c.cipher.Encrypt(plaintext, c.salt)
+}
+
+// Decrypt implements decrypt and authenticates ciphertext
+func (c *Column) Decrypt(ciphertext []byte) ([]byte, error) {
+	return c.cipher.Decrypt(ciphertext, c.salt)
+}
+
+// BlindIndex returns truncated keyed hash (HMAC) of plaintext for lookup columns.
+// Compute it from plaintext at query time: WHERE email_idx = ?
+func (c *Column) BlindIndex(plaintext []byte) []byte {
+	mac := hmac.New(c.hkdfHash, c.indexKey)
+	mac.Write(plaintext)
+	return mac.Sum(nil)[:c.indexLen]
+}
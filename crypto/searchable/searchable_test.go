@@ -0,0 +1,117 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package searchable
+
+import (
+	"crypto/sha512"
+	"testing"
+
+	"github.com/keecon/pkg-go/crypto/cipher"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeterministicEncrypt(t *testing.T) {
+	// given
+	s := New("test-secret", WithHKDFInfo([]byte("test-info")))
+	email, err := s.Column("users.email")
+	assert.NoError(t, err)
+	phone, err := s.Column("users.phone")
+	assert.NoError(t, err)
+	plaintext := []byte("user@example.com")
+
+	// when
+	ciphertext1, err := email.Encrypt(plaintext)
+	assert.NoError(t, err)
+
+	ciphertext2, err := email.Encrypt(plaintext)
+	assert.NoError(t, err)
+
+	other, err := phone.Encrypt(plaintext)
+	assert.NoError(t, err)
+
+	rettext, err := email.Decrypt(ciphertext1)
+	assert.NoError(t, err)
+
+	_, errColumn := phone.Decrypt(ciphertext1)
+
+	// then
+	assert.Equal(t, ciphertext1, ciphertext2)
+	assert.NotEqual(t, ciphertext1, other)
+	assert.Equal(t, plaintext, rettext)
+	assert.Error(t, errColumn)
+}
+
+func TestBlindIndex(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name     string
+		opts     []Option
+		indexLen int
+	}{
+		{
+			name:     "Default",
+			indexLen: 8,
+		},
+		{
+			name:     "Length4",
+			opts:     []Option{WithIndexLength(4)},
+			indexLen: 4,
+		},
+		{
+			name:     "SHA512Length64",
+			opts:     []Option{WithHKDFHash(sha512.New), WithIndexLength(64)},
+			indexLen: 64,
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			s := New("test-secret", v.opts...)
+			email, err := s.Column("users.email")
+			assert.NoError(t, err)
+			phone, err := s.Column("users.phone")
+			assert.NoError(t, err)
+
+			// when
+			index1 := email.BlindIndex([]byte("user@example.com"))
+			index2 := email.BlindIndex([]byte("user@example.com"))
+			other := email.BlindIndex([]byte("other@example.com"))
+			otherColumn := phone.BlindIndex([]byte("user@example.com"))
+
+			// then
+			assert.Len(t, index1, v.indexLen)
+			assert.Equal(t, index1, index2)
+			assert.NotEqual(t, index1, other)
+			assert.NotEqual(t, index1, otherColumn)
+		})
+	}
+}
+
+func TestInvalidIndexLength(t *testing.T) {
+	// given
+	s := New("test-secret", WithIndexLength(33))
+
+	// when
+	_, err := s.Column("users.email")
+
+	// then
+	assert.Error(t, err)
+}
+
+func TestSearchableClose(t *testing.T) {
+	// given
+	secret := cipher.NewSecret([]byte("test-secret"))
+	s := NewFromSecret(secret)
+
+	// when
+	errClose := s.Close()
+	_, err := s.Column("users.email")
+
+	// then
+	assert.NoError(t, errClose)
+	assert.ErrorIs(t, err, cipher.ErrSecretClosed)
+}
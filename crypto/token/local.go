@@ -0,0 +1,161 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package token
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/chacha20"
+)
+
+// v4.local token layout
+//
+//	v4.local.base64url(nonce || ciphertext || tag)[.base64url(footer)]
+//
+// The nonce is 32 random bytes. The encryption key and the XChaCha20 nonce
+// are derived from the key and the nonce with keyed BLAKE2b, the tag is
+// keyed BLAKE2b-256 of the pre-authentication encoding (PAE) of
+// the header, the nonce, the ciphertext, the footer and the implicit assertion.
+const (
+	localHeader   = "v4.local."
+	localNonceLen = 32
+	localTagLen   = 32
+	localKeyLen   = 32
+)
+
+// BLAKE2b key derivation labels
+var (
+	encryptionKeyLabel = []byte("paseto-encryption-key")
+	authKeyLabel       = []byte("paseto-auth-key-for-aead")
+)
+
+var b64 = base64.RawURLEncoding
+
+func sealLocal(key, message, footer, implicit []byte) (string, error) {
+	nonce := make([]byte, localNonceLen)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("read random nonce: %w", err)
+	}
+	return sealLocalWithNonce(key, nonce, message, footer, implicit)
+}
+
+func sealLocalWithNonce(key, nonce, message, footer, implicit []byte) (string, error) {
+	ek, n2, ak, err := splitLocalKey(key, nonce)
+	if err != nil {
+		return "", err
+	}
+
+	c, err := chacha20.NewUnauthenticatedCipher(ek, n2)
+	if err != nil {
+		return "", fmt.Errorf("new xchacha20: %w", err)
+	}
+	ciphertext := make([]byte, len(message))
+	c.XORKeyStream(ciphertext, message)
+
+	tag, err := localTag(ak, nonce, ciphertext, footer, implicit)
+	if err != nil {
+		return "", err
+	}
+
+	token := localHeader + b64.EncodeToString(slices.Concat(nonce, ciphertext, tag))
+	if len(footer) != 0 {
+		token += "." + b64.EncodeToString(footer)
+	}
+	return token, nil
+}
+
+func openLocal(key []byte, token string, footer, implicit []byte) ([]byte, error) {
+	body, ok := strings.CutPrefix(token, localHeader)
+	if !ok {
+		return nil, fmt.Errorf("%w: header", ErrInvalidToken)
+	}
+	body, _, _ = strings.Cut(body, ".")
+
+	raw, err := b64.DecodeString(body)
+	if err != nil || len(raw) < localNonceLen+localTagLen {
+		return nil, fmt.Errorf("%w: payload", ErrInvalidToken)
+	}
+	nonce := raw[:localNonceLen]
+	ciphertext := raw[localNonceLen : len(raw)-localTagLen]
+	tag := raw[len(raw)-localTagLen:]
+
+	ek, n2, ak, err := splitLocalKey(key, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	want, err := localTag(ak, nonce, ciphertext, footer, implicit)
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare(tag, want) != 1 {
+		return nil, ErrInvalidToken
+	}
+
+	c, err := chacha20.NewUnauthenticatedCipher(ek, n2)
+	if err != nil {
+		return nil, fmt.Errorf("new xchacha20: %w", err)
+	}
+	message := make([]byte, len(ciphertext))
+	c.XORKeyStream(message, ciphertext)
+	return message, nil
+}
+
+// splitLocalKey derives the encryption key, the XChaCha20 nonce and the authentication key
+func splitLocalKey(key, nonce []byte) (ek, n2, ak []byte, err error) {
+	if len(key) != localKeyLen {
+		return nil, nil, nil, fmt.Errorf("invalid key length: %d", len(key))
+	}
+
+	h, err := blake2b.New(localKeyLen+chacha20.NonceSizeX, key)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("new blake2b: %w", err)
+	}
+	h.Write(encryptionKeyLabel)
+	h.Write(nonce)
+	tmp := h.Sum(nil)
+
+	h, err = blake2b.New256(key)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("new blake2b: %w", err)
+	}
+	h.Write(authKeyLabel)
+	h.Write(nonce)
+	return tmp[:localKeyLen], tmp[localKeyLen:], h.Sum(nil), nil
+}
+
+func localTag(ak, nonce, ciphertext, footer, implicit []byte) ([]byte, error) {
+	h, err := blake2b.New256(ak)
+	if err != nil {
+		return nil, fmt.Errorf("new blake2b: %w", err)
+	}
+	h.Write(pae([]byte(localHeader), nonce, ciphertext, footer, implicit))
+	return h.Sum(nil), nil
+}
+
+// pae implements pre-authentication encoding, it encodes the count and
+// the length of every piece as 64-bit little endian with the top bit cleared
+func pae(pieces ...[]byte) []byte {
+	n := 8
+	for _, p := range pieces {
+		n += 8 + len(p)
+	}
+
+	b := make([]byte, 0, n)
+	b = binary.LittleEndian.AppendUint64(b, uint64(len(pieces))&^(1<<63))
+	for _, p := range pieces {
+		b = binary.LittleEndian.AppendUint64(b, uint64(len(p))&^(1<<63))
+		b = append(b, p...)
+	}
+	return b
+}
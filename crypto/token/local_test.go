@@ -0,0 +1,111 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package token
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// PASETO v4.local test vectors (4-E-1, 4-E-5, 4-E-7)
+func TestLocalVectors(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name     string
+		nonce    string
+		payload  string
+		footer   string
+		implicit string
+		token    string
+	}{
+		{
+			name:    "4E1",
+			nonce:   "0000000000000000000000000000000000000000000000000000000000000000",
+			payload: `{"data":"this is a secret message","exp":"2022-01-01T00:00:00+00:00"}`,
+			token:   "v4.local.AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAr68PS4AXe7If_ZgesdkUMvSwscFlAl1pk5HC0e8kApeaqMfGo_7OpBnwJOAbY9V7WU6abu74MmcUE8YWAiaArVI8XJ5hOb_4v9RmDkneN0S92dx0OW4pgy7omxgf3S8c3LlQg",
+		},
+		{
+			name:    "4E5",
+			nonce:   "df654812bac492663825520ba2f6e67cf5ca5bdc13d4e7507a98cc4c2fcc3ad8",
+			payload: `{"data":"this is a secret message","exp":"2022-01-01T00:00:00+00:00"}`,
+			footer:  `{"kid":"zVhMiPBP9fRf2snEcT7gFTioeA9COcNy9DfgL1W60haN"}`,
+			token:   "v4.local.32VIErrEkmY4JVILovbmfPXKW9wT1OdQepjMTC_MOtjA4kiqw7_tcaOM5GNEcnTxl60WkwMsYXw6FSNb_UdJPXjpzm0KW9ojM5f4O2mRvE2IcweP-PRdoHjd5-RHCiExR1IK6t4x-RMNXtQNbz7FvFZ_G-lFpk5RG3EOrwDL6CgDqcerSQ.eyJraWQiOiJ6VmhNaVBCUDlmUmYyc25FY1Q3Z0ZUaW9lQTlDT2NOeTlEZmdMMVc2MGhhTiJ9",
+		},
+		{
+			name:     "4E7",
+			nonce:    "df654812bac492663825520ba2f6e67cf5ca5bdc13d4e7507a98cc4c2fcc3ad8",
+			payload:  `{"data":"this is a secret message","exp":"2022-01-01T00:00:00+00:00"}`,
+			footer:   `{"kid":"zVhMiPBP9fRf2snEcT7gFTioeA9COcNy9DfgL1W60haN"}`,
+			implicit: `{"test-vector":"4-E-7"}`,
+			token:    "v4.local.32VIErrEkmY4JVILovbmfPXKW9wT1OdQepjMTC_MOtjA4kiqw7_tcaOM5GNEcnTxl60WkwMsYXw6FSNb_UdJPXjpzm0KW9ojM5f4O2mRvE2IcweP-PRdoHjd5-RHCiExR1IK6t40KCCWLA7GYL9KFHzKlwY9_RnIfRrMQpueydLEAZGGcA.eyJraWQiOiJ6VmhNaVBCUDlmUmYyc25FY1Q3Z0ZUaW9lQTlDT2NOeTlEZmdMMVc2MGhhTiJ9",
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			key := decodeHex(t, "707172737475767778797a7b7c7d7e7f808182838485868788898a8b8c8d8e8f")
+			nonce := decodeHex(t, v.nonce)
+
+			// when
+			token, err := sealLocalWithNonce(key, nonce, []byte(v.payload), []byte(v.footer), []byte(v.implicit))
+			assert.NoError(t, err)
+			payload, openErr := openLocal(key, v.token, []byte(v.footer), []byte(v.implicit))
+			_, tamperErr := openLocal(key, v.token, []byte(v.footer), []byte("tampered"))
+
+			// then
+			assert.Equal(t, v.token, token)
+			assert.NoError(t, openErr)
+			assert.Equal(t, v.payload, string(payload))
+			assert.ErrorIs(t, tamperErr, ErrInvalidToken)
+		})
+	}
+}
+
+func TestPAE(t *testing.T) {
+	// dataset
+	dataset := []struct {
+		name   string
+		pieces [][]byte
+		want   string
+	}{
+		{
+			name: "Empty",
+			want: "0000000000000000",
+		},
+		{
+			name:   "EmptyPiece",
+			pieces: [][]byte{{}},
+			want:   "01000000000000000000000000000000",
+		},
+		{
+			name:   "Test",
+			pieces: [][]byte{[]byte("test")},
+			want:   "0100000000000000040000000000000074657374",
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// when
+			got := pae(v.pieces...)
+
+			// then
+			assert.Equal(t, v.want, hex.EncodeToString(got))
+		})
+	}
+}
+
+func decodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	assert.NoError(t, err)
+	return b
+}
@@ -0,0 +1,332 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package token implements encrypted and authenticated tokens in PASETO v4.local format
+package token
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keecon/pkg-go/crypto/cipher"
+	"golang.org/x/crypto/hkdf"
+)
+
+// HKDF info label separates token keys from other keys of the same secret
+var keyLabel = []byte("token/v4.local")
+
+var (
+	// ErrInvalidToken is returned when token is malformed or fails authentication
+	ErrInvalidToken = errors.New("token: invalid token")
+
+	// ErrExpired is returned when token is expired
+	ErrExpired = errors.New("token: expired")
+
+	// ErrNotYetValid is returned when token is used before nbf or iat
+	ErrNotYetValid = errors.New("token: not yet valid")
+
+	// ErrAudienceMismatch is returned when token audience differs from the configured one
+	ErrAudienceMismatch = errors.New("token: audience mismatch")
+
+	// ErrKeyNotFound is returned when footer key id is not in the manager
+	ErrKeyNotFound = errors.New("token: key not found")
+
+	// ErrKeyExists is returned when key id is already in the manager
+	ErrKeyExists = errors.New("token: key already exists")
+
+	// ErrNoPrimaryKey is returned when the manager has no primary key to issue
+	ErrNoPrimaryKey = errors.New("token: no primary key")
+
+	// ErrPrimaryKey is returned when retiring the primary key
+	ErrPrimaryKey = errors.New("token: primary key cannot be retired")
+)
+
+// Claims holds registered claims, embed it in a struct to add custom claims
+type Claims struct {
+	Issuer     string    `json:"iss,omitempty"`
+	Subject    string    `json:"sub,omitempty"`
+	Audience   string    `json:"aud,omitempty"`
+	Expiration time.Time `json:"exp,omitzero"`
+	NotBefore  time.Time `json:"nbf,omitzero"`
+	IssuedAt   time.Time `json:"iat,omitzero"`
+	ID         string    `json:"jti,omitempty"`
+}
+
+// RegisteredClaims returns registered claims
+func (c *Claims) RegisteredClaims() *Claims {
+	return c
+}
+
+// Claimer is implemented by structs that embed Claims
+type Claimer interface {
+	RegisteredClaims() *Claims
+}
+
+// footer is the token footer, it is authenticated but not encrypted
+type footer struct {
+	KeyID string `json:"kid"`
+}
+
+// Manager issues and verifies tokens. Tokens are issued with the primary key
+// and record its id in the footer, Verify selects the key that issued the token.
+// It is safe for concurrent use.
+type Manager struct {
+	hkdfHash func() hash.Hash
+	hkdfInfo []byte
+	implicit []byte
+	audience string
+	ttl      time.Duration
+	leeway   time.Duration
+	now      func() time.Time
+
+	mu         sync.RWMutex
+	keys       map[string][]byte
+	primary    string
+	hasPrimary bool
+}
+
+// Option defines configure Manager settings
+type Option func(*Manager)
+
+// NewManager creates Manager
+func NewManager(opts ...Option) *Manager {
+	ret := &Manager{
+		hkdfHash: sha256.New, // recommends
+		now:      time.Now,
+		keys:     make(map[string][]byte),
+	}
+
+	for _, o := range opts {
+		o(ret)
+	}
+	return ret
+}
+
+// WithHKDFHash configures Key Derivation Function (HKDF)
+func WithHKDFHash(fn func() hash.Hash) Option {
+	return func(m *Manager) {
+		m.hkdfHash = fn
+	}
+}
+
+// WithHKDFInfo configures Key Derivation Function (HKDF) info
+func WithHKDFInfo(info []byte) Option {
+	return func(m *Manager) {
+		m.hkdfInfo = info
+	}
+}
+
+// WithImplicitAssertion configures implicit assertion, it is authenticated
+// but not stored in the token (e.g. a user id of the session table)
+func WithImplicitAssertion(b []byte) Option {
+	return func(m *Manager) {
+		m.implicit = b
+	}
+}
+
+// WithAudience configures the expected audience, Issue fills it when empty
+func WithAudience(aud string) Option {
+	return func(m *Manager) {
+		m.audience = aud
+	}
+}
+
+// WithTTL configures the lifetime, Issue fills exp when empty
+func WithTTL(d time.Duration) Option {
+	return func(m *Manager) {
+		m.ttl = d
+	}
+}
+
+// WithLeeway configures the allowed clock skew of exp, nbf and iat
+func WithLeeway(d time.Duration) Option {
+	return func(m *Manager) {
+		m.leeway = d
+	}
+}
+
+// WithClock configures the clock of Issue and Verify
+func WithClock(now func() time.Time) Option {
+	return func(m *Manager) {
+		m.now = now
+	}
+}
+
+// Add adds secret as verify-only key, it is used to issue after Promote.
+// The token key is derived from the secret with HKDF, the secret is not retained.
+func (m *Manager) Add(kid string, secret *cipher.Secret) error {
+	ikm, err := secret.Bytes()
+	if err != nil {
+		return err
+	}
+
+	key := make([]byte, localKeyLen)
+	kdf := hkdf.New(m.hkdfHash, ikm, []byte(kid), slices.Concat(m.hkdfInfo, keyLabel))
+	if _, err := kdf.Read(key); err != nil {
+		return fmt.Errorf("hkdf expand key: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.keys[kid]; ok {
+		return fmt.Errorf("%w: %s", ErrKeyExists, kid)
+	}
+
+	m.keys[kid] = key
+	return nil
+}
+
+// Promote makes the key primary, the previous primary key remains verify-only
+func (m *Manager) Promote(kid string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.keys[kid]; !ok {
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, kid)
+	}
+
+	m.primary = kid
+	m.hasPrimary = true
+	return nil
+}
+
+// Retire removes the key, tokens issued by it can no longer be verified
+func (m *Manager) Retire(kid string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key, ok := m.keys[kid]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, kid)
+	}
+	if m.hasPrimary && m.primary == kid {
+		return fmt.Errorf("%w: %s", ErrPrimaryKey, kid)
+	}
+
+	clear(key)
+	delete(m.keys, kid)
+	return nil
+}
+
+// Issue returns token of claims with the primary key.
+// It fills empty iat with the clock, empty exp with WithTTL and empty aud with WithAudience.
+func (m *Manager) Issue(v Claimer) (string, error) {
+	kid, key, ok := m.primaryKey()
+	if !ok {
+		return "", ErrNoPrimaryKey
+	}
+	defer clear(key)
+
+	c := v.RegisteredClaims()
+	now := m.now()
+	if c.IssuedAt.IsZero() {
+		c.IssuedAt = now
+	}
+	if c.Expiration.IsZero() && 0 < m.ttl {
+		c.Expiration = now.Add(m.ttl)
+	}
+	if c.Audience == "" {
+		c.Audience = m.audience
+	}
+
+	message, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("marshal claims: %w", err)
+	}
+	f, err := json.Marshal(footer{KeyID: kid})
+	if err != nil {
+		return "", fmt.Errorf("marshal footer: %w", err)
+	}
+	return sealLocal(key, message, f, m.implicit)
+}
+
+// Verify authenticates token, decodes claims into v and validates exp, nbf, iat and aud
+func (m *Manager) Verify(token string, v Claimer) error {
+	kid, f, err := parseFooter(token)
+	if err != nil {
+		return err
+	}
+
+	key, ok := m.key(kid)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, kid)
+	}
+	defer clear(key)
+
+	message, err := openLocal(key, token, f, m.implicit)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(message, v); err != nil {
+		return fmt.Errorf("%w: claims: %w", ErrInvalidToken, err)
+	}
+	return m.validate(v.RegisteredClaims())
+}
+
+// primaryKey returns the primary key id and a copy of its key, Retire zeroes the key in the manager
+func (m *Manager) primaryKey() (string, []byte, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if !m.hasPrimary {
+		return "", nil, false
+	}
+	return m.primary, slices.Clone(m.keys[m.primary]), true
+}
+
+// key returns a copy of the key, Retire zeroes the key in the manager
+func (m *Manager) key(kid string) ([]byte, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	key, ok := m.keys[kid]
+	if !ok {
+		return nil, false
+	}
+	return slices.Clone(key), true
+}
+
+func (m *Manager) validate(c *Claims) error {
+	now := m.now()
+	if !c.Expiration.IsZero() && now.After(c.Expiration.Add(m.leeway)) {
+		return fmt.Errorf("%w: at %s", ErrExpired, c.Expiration.Format(time.RFC3339))
+	}
+	if !c.NotBefore.IsZero() && now.Add(m.leeway).Before(c.NotBefore) {
+		return fmt.Errorf("%w: nbf %s", ErrNotYetValid, c.NotBefore.Format(time.RFC3339))
+	}
+	if !c.IssuedAt.IsZero() && now.Add(m.leeway).Before(c.IssuedAt) {
+		return fmt.Errorf("%w: iat %s", ErrNotYetValid, c.IssuedAt.Format(time.RFC3339))
+	}
+	if m.audience != "" && c.Audience != m.audience {
+		return fmt.Errorf("%w: %q", ErrAudienceMismatch, c.Audience)
+	}
+	return nil
+}
+
+// parseFooter returns the key id and the raw footer of token
+func parseFooter(token string) (string, []byte, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 4 {
+		return "", nil, fmt.Errorf("%w: footer", ErrInvalidToken)
+	}
+
+	raw, err := b64.DecodeString(parts[3])
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: footer", ErrInvalidToken)
+	}
+
+	var f footer
+	if err := json.Unmarshal(raw, &f); err != nil || f.KeyID == "" {
+		return "", nil, fmt.Errorf("%w: footer", ErrInvalidToken)
+	}
+	return f.KeyID, raw, nil
+}
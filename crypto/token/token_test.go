@@ -0,0 +1,277 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package token
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keecon/pkg-go/crypto/cipher"
+	"github.com/stretchr/testify/assert"
+)
+
+type sessionClaims struct {
+	Claims
+	Role string `json:"role"`
+}
+
+func newManager(t *testing.T, now *time.Time, opts ...Option) *Manager {
+	t.Helper()
+
+	opts = append([]Option{WithClock(func() time.Time { return *now })}, opts...)
+	m := NewManager(opts...)
+	assert.NoError(t, m.Add("k1", cipher.NewSecret([]byte("secret-1"))))
+	assert.NoError(t, m.Promote("k1"))
+	return m
+}
+
+func TestManagerIssueVerify(t *testing.T) {
+	// given
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := newManager(t, &now, WithTTL(time.Hour), WithAudience("api"))
+
+	// when
+	token, err := m.Issue(&sessionClaims{Claims: Claims{Subject: "user-1"}, Role: "admin"})
+	assert.NoError(t, err)
+	var got sessionClaims
+	verifyErr := m.Verify(token, &got)
+
+	// then
+	assert.True(t, strings.HasPrefix(token, "v4.local."))
+	assert.NotContains(t, token, "admin")
+	assert.NoError(t, verifyErr)
+	assert.Equal(t, "user-1", got.Subject)
+	assert.Equal(t, "admin", got.Role)
+	assert.Equal(t, "api", got.Audience)
+	assert.True(t, now.Equal(got.IssuedAt))
+	assert.True(t, now.Add(time.Hour).Equal(got.Expiration))
+}
+
+func TestManagerValidate(t *testing.T) {
+	// given
+	issued := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// dataset
+	dataset := []struct {
+		name   string
+		claims Claims
+		opts   []Option
+		at     time.Duration
+		err    error
+	}{
+		{
+			name:   "Valid",
+			claims: Claims{Expiration: issued.Add(time.Hour)},
+			at:     time.Minute,
+		},
+		{
+			name:   "Expired",
+			claims: Claims{Expiration: issued.Add(time.Hour)},
+			at:     time.Hour + time.Second,
+			err:    ErrExpired,
+		},
+		{
+			name:   "ExpiredWithinLeeway",
+			claims: Claims{Expiration: issued.Add(time.Hour)},
+			opts:   []Option{WithLeeway(time.Minute)},
+			at:     time.Hour + time.Second,
+		},
+		{
+			name:   "NotBefore",
+			claims: Claims{NotBefore: issued.Add(time.Hour)},
+			at:     time.Minute,
+			err:    ErrNotYetValid,
+		},
+		{
+			name:   "IssuedInFuture",
+			claims: Claims{IssuedAt: issued.Add(time.Hour)},
+			err:    ErrNotYetValid,
+		},
+		{
+			name:   "AudienceMismatch",
+			claims: Claims{Audience: "web"},
+			opts:   []Option{WithAudience("api")},
+			err:    ErrAudienceMismatch,
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			now := issued
+			m := newManager(t, &now, v.opts...)
+			claims := v.claims
+			token, err := m.Issue(&claims)
+			assert.NoError(t, err)
+
+			// when
+			now = issued.Add(v.at)
+			err = m.Verify(token, &Claims{})
+
+			// then
+			if v.err != nil {
+				assert.ErrorIs(t, err, v.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestManagerRotation(t *testing.T) {
+	// given
+	now := time.Now()
+	m := newManager(t, &now)
+	old, err := m.Issue(&Claims{Subject: "old"})
+	assert.NoError(t, err)
+
+	// when
+	assert.NoError(t, m.Add("k2", cipher.NewSecret([]byte("secret-2"))))
+	assert.NoError(t, m.Promote("k2"))
+	token, err := m.Issue(&Claims{Subject: "new"})
+	assert.NoError(t, err)
+	var oldClaims, newClaims Claims
+	oldErr := m.Verify(old, &oldClaims)
+	newErr := m.Verify(token, &newClaims)
+	retireErr := m.Retire("k1")
+	retiredErr := m.Verify(old, &Claims{})
+
+	// then
+	assert.NoError(t, oldErr)
+	assert.NoError(t, newErr)
+	assert.Equal(t, "old", oldClaims.Subject)
+	assert.Equal(t, "new", newClaims.Subject)
+	assert.NoError(t, retireErr)
+	assert.ErrorIs(t, retiredErr, ErrKeyNotFound)
+	assert.ErrorIs(t, m.Retire("k2"), ErrPrimaryKey)
+	assert.ErrorIs(t, m.Add("k2", cipher.NewSecret([]byte("secret-2"))), ErrKeyExists)
+}
+
+func TestManagerConcurrentRetire(t *testing.T) {
+	// given
+	now := time.Now()
+	m := newManager(t, &now)
+	secrets := map[string]string{"k1": "secret-1", "k2": "secret-2"}
+
+	done := make(chan struct{})
+	rotated := make(chan struct{})
+	go func() {
+		defer close(rotated)
+		from, to := "k1", "k2"
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			assert.NoError(t, m.Add(to, cipher.NewSecret([]byte(secrets[to]))))
+			assert.NoError(t, m.Promote(to))
+			assert.NoError(t, m.Retire(from))
+			from, to = to, from
+		}
+	}()
+
+	// when
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 500 {
+				token, err := m.Issue(&Claims{Subject: "user-1"})
+				if !assert.NoError(t, err) {
+					return
+				}
+				var got Claims
+				if err := m.Verify(token, &got); err != nil {
+					assert.ErrorIs(t, err, ErrKeyNotFound)
+					continue
+				}
+				assert.Equal(t, "user-1", got.Subject)
+			}
+		}()
+	}
+	wg.Wait()
+	close(done)
+	<-rotated
+
+	token, err := m.Issue(&Claims{Subject: "user-2"})
+	assert.NoError(t, err)
+
+	// then
+	var got Claims
+	assert.NoError(t, m.Verify(token, &got))
+	assert.Equal(t, "user-2", got.Subject)
+}
+
+func TestManagerErrors(t *testing.T) {
+	// given
+	now := time.Now()
+	m := newManager(t, &now)
+	other := NewManager(WithImplicitAssertion([]byte("session-1")))
+	assert.NoError(t, other.Add("k1", cipher.NewSecret([]byte("secret-1"))))
+	token, err := m.Issue(&Claims{})
+	assert.NoError(t, err)
+	parts := strings.Split(token, ".")
+	tampered := []byte(parts[2])
+	tampered[0] ^= 'A' ^ 'B'
+
+	// dataset
+	dataset := []struct {
+		name  string
+		m     *Manager
+		token string
+		err   error
+	}{
+		{
+			name:  "NoFooter",
+			m:     m,
+			token: strings.Join(parts[:3], "."),
+			err:   ErrInvalidToken,
+		},
+		{
+			name:  "UnknownKey",
+			m:     m,
+			token: strings.Join(parts[:3], ".") + ".eyJraWQiOiJrOSJ9",
+			err:   ErrKeyNotFound,
+		},
+		{
+			name:  "TamperedPayload",
+			m:     m,
+			token: strings.Join([]string{parts[0], parts[1], string(tampered), parts[3]}, "."),
+			err:   ErrInvalidToken,
+		},
+		{
+			name:  "ImplicitAssertionMismatch",
+			m:     other,
+			token: token,
+			err:   ErrInvalidToken,
+		},
+		{
+			name:  "PublicToken",
+			m:     m,
+			token: "v4.public." + parts[2] + "." + parts[3],
+			err:   ErrInvalidToken,
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// when
+			err := v.m.Verify(v.token, &Claims{})
+
+			// then
+			assert.ErrorIs(t, err, v.err)
+		})
+	}
+
+	_, err = NewManager().Issue(&Claims{})
+	assert.ErrorIs(t, err, ErrNoPrimaryKey)
+}
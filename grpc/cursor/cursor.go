@@ -0,0 +1,172 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cursor implements opaque encrypted page tokens for gRPC List APIs
+package cursor
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/keecon/pkg-go/crypto/cipher"
+	"github.com/keecon/pkg-go/grpc/status"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+const (
+	saltLen       = 16
+	filterHashLen = 16
+)
+
+// Codec encodes cursors of type T into page tokens and decodes them.
+// Tokens are encrypted and authenticated, bound to the codec name and the filter,
+// and expire after the TTL.
+type Codec[T any] struct {
+	options
+	cipher *cipher.AES
+	name   []byte
+}
+
+// options holds Codec settings, it is not generic to share Option between codecs
+type options struct {
+	field string
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+// Option defines configure Codec settings
+type Option func(*options)
+
+// New creates Codec, name (e.g. "/pkg.UserService/ListUsers") is authenticated,
+// so a token of a List API is rejected by the others
+func New[T any](c *cipher.AES, name string, opts ...Option) *Codec[T] {
+	o := &options{
+		field: "page_token",
+		ttl:   24 * time.Hour,
+		now:   time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &Codec[T]{
+		options: *o,
+		cipher:  c,
+		name:    []byte(name),
+	}
+}
+
+// WithField configures the request field name of BadRequest violations
+func WithField(name string) Option {
+	return func(o *options) {
+		o.field = name
+	}
+}
+
+// WithTTL configures the lifetime of tokens
+func WithTTL(d time.Duration) Option {
+	return func(o *options) {
+		o.ttl = d
+	}
+}
+
+// WithClock configures the clock of Encode and Decode
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.now = now
+	}
+}
+
+// payload is the plaintext of a token
+type payload[T any] struct {
+	Cursor     T      `json:"c"`
+	FilterHash []byte `json:"f"`
+	Expiration int64  `json:"e"`
+}
+
+// Encode returns page token of cursor (e.g. the last key and the sort order).
+// filter is the request parameters that must not change between pages
+// (e.g. filter and order_by), only its hash is stored.
+func (c *Codec[T]) Encode(cursor T, filter ...string) (string, error) {
+	b, err := json.Marshal(payload[T]{
+		Cursor:     cursor,
+		FilterHash: HashFilter(filter...),
+		Expiration: c.now().Add(c.ttl).Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal cursor: %w", err)
+	}
+
+	// a random salt per token, the cipher may derive the nonce from the salt
+	salt := make([]byte, saltLen)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return "", fmt.Errorf("read random salt: %w", err)
+	}
+
+	ciphertext, err := c.cipher.EncryptWithAAD(b, salt, c.name)
+	if err != nil {
+		return "", fmt.Errorf("encrypt cursor: %w", err)
+	}
+	return cipher.RawURLBase64.EncodeToString(append(salt, ciphertext...)), nil
+}
+
+// Decode returns cursor of page token, it returns nil for an empty token (the first page).
+// It returns status InvalidArgument with BadRequest detail when token is malformed,
+// tampered, expired or reused with a different filter.
+func (c *Codec[T]) Decode(token string, filter ...string) (*T, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	b, err := cipher.RawURLBase64.DecodeString(token)
+	if err != nil || len(b) <= saltLen {
+		return nil, c.invalid("malformed page token")
+	}
+
+	plaintext, err := c.cipher.DecryptWithAAD(b[saltLen:], b[:saltLen], c.name)
+	if err != nil {
+		return nil, c.invalid("malformed page token")
+	}
+
+	var p payload[T]
+	if err := json.Unmarshal(plaintext, &p); err != nil {
+		return nil, c.invalid("malformed page token")
+	}
+	if c.now().Unix() > p.Expiration {
+		return nil, c.invalid("expired page token")
+	}
+	if subtle.ConstantTimeCompare(p.FilterHash, HashFilter(filter...)) != 1 {
+		return nil, c.invalid("page token does not match the request parameters")
+	}
+	return &p.Cursor, nil
+}
+
+func (c *Codec[T]) invalid(description string) error {
+	s := status.InvalidArgument("invalid %s", c.field)
+	if d, err := s.WithDetails(&status.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: c.field, Description: description},
+		},
+	}); err == nil {
+		s = d
+	}
+	return s.Err()
+}
+
+// HashFilter returns truncated SHA-256 of length-prefixed parts
+func HashFilter(parts ...string) []byte {
+	h := sha256.New()
+	for _, p := range parts {
+		_ = binary.Write(h, binary.BigEndian, uint32(len(p)))
+		h.Write([]byte(p))
+	}
+	return h.Sum(nil)[:filterHashLen]
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cursor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keecon/pkg-go/crypto/cipher"
+	"github.com/keecon/pkg-go/grpc/status"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+type userCursor struct {
+	LastID    int64  `json:"id"`
+	LastName  string `json:"name"`
+	Ascending bool   `json:"asc"`
+}
+
+func TestCodecEncodeDecode(t *testing.T) {
+	// given
+	c := New[userCursor](cipher.NewAES("secret"), "/test.UserService/ListUsers")
+	cursor := userCursor{LastID: 42, LastName: "kim", Ascending: true}
+
+	// when
+	token, err := c.Encode(cursor, "status=active", "name asc")
+	assert.NoError(t, err)
+	other, err := c.Encode(cursor, "status=active", "name asc")
+	assert.NoError(t, err)
+	got, decodeErr := c.Decode(token, "status=active", "name asc")
+	first, firstErr := c.Decode("")
+
+	// then
+	assert.NoError(t, decodeErr)
+	assert.Equal(t, &cursor, got)
+	assert.NotEqual(t, token, other)
+	assert.NotContains(t, token, "kim")
+	assert.NoError(t, firstErr)
+	assert.Nil(t, first)
+}
+
+func TestCodecDecode(t *testing.T) {
+	// given
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	c := New[userCursor](cipher.NewAES("secret"), "/test.UserService/ListUsers",
+		WithTTL(time.Hour), WithClock(clock), WithField("next_page_token"))
+	token, err := c.Encode(userCursor{LastID: 42}, "status=active")
+	assert.NoError(t, err)
+	b, err := cipher.RawURLBase64.DecodeString(token)
+	assert.NoError(t, err)
+	b[len(b)-1] ^= 1
+	tampered := cipher.RawURLBase64.EncodeToString(b)
+
+	// dataset
+	dataset := []struct {
+		name        string
+		codec       *Codec[userCursor]
+		token       string
+		filter      []string
+		at          time.Duration
+		description string
+	}{
+		{
+			name:        "Malformed",
+			codec:       c,
+			token:       "not-a-token!",
+			filter:      []string{"status=active"},
+			description: "malformed page token",
+		},
+		{
+			name:        "Tampered",
+			codec:       c,
+			token:       tampered,
+			filter:      []string{"status=active"},
+			description: "malformed page token",
+		},
+		{
+			name: "OtherAPI",
+			codec: New[userCursor](cipher.NewAES("secret"), "/test.UserService/ListGroups",
+				WithClock(clock), WithField("next_page_token")),
+			token:       token,
+			filter:      []string{"status=active"},
+			description: "malformed page token",
+		},
+		{
+			name:        "Expired",
+			codec:       c,
+			token:       token,
+			filter:      []string{"status=active"},
+			at:          time.Hour + time.Second,
+			description: "expired page token",
+		},
+		{
+			name:        "FilterMismatch",
+			codec:       c,
+			token:       token,
+			filter:      []string{"status=deleted"},
+			description: "page token does not match the request parameters",
+		},
+		{
+			name:        "FilterSplit",
+			codec:       c,
+			token:       token,
+			filter:      []string{"status=", "active"},
+			description: "page token does not match the request parameters",
+		},
+	}
+
+	// table driven tests
+	for _, v := range dataset {
+		t.Run(v.name, func(t *testing.T) {
+			// given
+			now = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Add(v.at)
+
+			// when
+			got, err := v.codec.Decode(v.token, v.filter...)
+
+			// then
+			assert.Nil(t, got)
+			s, ok := status.FromError(err)
+			assert.True(t, ok)
+			assert.Equal(t, codes.InvalidArgument, s.Code())
+			assert.Len(t, s.Details(), 1)
+			br, ok := s.Details()[0].(*status.BadRequest)
+			assert.True(t, ok)
+			assert.Equal(t, "next_page_token", br.GetFieldViolations()[0].GetField())
+			assert.Equal(t, v.description, br.GetFieldViolations()[0].GetDescription())
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package sensitive decrypts sensitive fields of requests and encrypts them of responses.
+// Interceptors before it (e.g. logging) see ciphertext, handlers see plaintext.
+package sensitive
+
+import (
+	"context"
+
+	"github.com/keecon/pkg-go/crypto/protocrypt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// UnaryServerInterceptor returns a new unary server interceptor that decrypts request and encrypts response sensitive fields.
+// The response is cloned before encrypting, so messages shared by the handler are not modified.
+func UnaryServerInterceptor(c *protocrypt.Crypter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := decrypt(c, req); err != nil {
+			return nil, err
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+
+		return encrypt(c, resp)
+	}
+}
+
+// StreamServerInterceptor returns a new streaming server interceptor that decrypts received and encrypts sent sensitive fields.
+func StreamServerInterceptor(c *protocrypt.Crypter) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: stream, crypter: c})
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	crypter *protocrypt.Crypter
+}
+
+func (s *serverStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return decrypt(s.crypter, m)
+}
+
+func (s *serverStream) SendMsg(m any) error {
+	m, err := encrypt(s.crypter, m)
+	if err != nil {
+		return err
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+func decrypt(c *protocrypt.Crypter, m any) error {
+	if msg, ok := m.(proto.Message); ok {
+		if err := c.Decrypt(msg); err != nil {
+			return status.Error(codes.InvalidArgument, "invalid sensitive field")
+		}
+	}
+	return nil
+}
+
+// encrypt returns encrypted clone of m
+func encrypt(c *protocrypt.Crypter, m any) (any, error) {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return m, nil
+	}
+
+	msg = proto.Clone(msg)
+	if err := c.Encrypt(msg); err != nil {
+		return nil, status.Error(codes.Internal, "encrypt sensitive field")
+	}
+	return msg, nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 KEECON CO.,LTD. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sensitive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keecon/pkg-go/crypto/cipher"
+	"github.com/keecon/pkg-go/crypto/protocrypt"
+	"github.com/keecon/pkg-go/proto/keecon"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// newTestMessage returns message with a sensitive email field
+func newTestMessage(t *testing.T, email string) *dynamicpb.Message {
+	opts := &descriptorpb.FieldOptions{}
+	proto.SetExtension(opts, keecon.E_Sensitive, true)
+
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("keecon/test/sensitive.proto"),
+		Package: proto.String("keecon.test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Account"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("email"),
+				JsonName: proto.String("email"),
+				Number:   proto.Int32(1),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				Options:  opts,
+			}},
+		}},
+	}, nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	msg := dynamicpb.NewMessage(fd.Messages().Get(0))
+	msg.Set(emailField(msg), protoreflect.ValueOfString(email))
+	return msg
+}
+
+func emailField(msg *dynamicpb.Message) protoreflect.FieldDescriptor {
+	return msg.Descriptor().Fields().ByName("email")
+}
+
+func email(msg any) string {
+	m := msg.(*dynamicpb.Message)
+	return m.Get(emailField(m)).String()
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	// given
+	c := protocrypt.New(cipher.NewAES("test-secret", cipher.WithRandomNonce()))
+	interceptor := UnaryServerInterceptor(c)
+
+	req := newTestMessage(t, "request@example.com")
+	assert.NoError(t, c.Encrypt(req))
+
+	shared := newTestMessage(t, "response@example.com")
+	var received string
+	handler := func(ctx context.Context, req any) (any, error) {
+		received = email(req)
+		return shared, nil
+	}
+
+	// when
+	resp, err := interceptor(context.Background(), req, &grpc.UnaryServerInfo{}, handler)
+	assert.NoError(t, err)
+
+	decrypted := proto.Clone(resp.(proto.Message))
+	assert.NoError(t, c.Decrypt(decrypted))
+
+	// then
+	assert.Equal(t, "request@example.com", received)
+	assert.NotEqual(t, "response@example.com", email(resp))
+	assert.Equal(t, "response@example.com", email(decrypted))
+	assert.Equal(t, "response@example.com", email(shared))
+}
+
+func TestUnaryServerInterceptorInvalid(t *testing.T) {
+	// given
+	c := protocrypt.New(cipher.NewAES("test-secret", cipher.WithRandomNonce()))
+	interceptor := UnaryServerInterceptor(c)
+	req := newTestMessage(t, "plaintext")
+
+	called := false
+	handler := func(ctx context.Context, req any) (any, error) {
+		called = true
+		return req, nil
+	}
+
+	// when
+	_, err := interceptor(context.Background(), req, &grpc.UnaryServerInfo{}, handler)
+
+	// then
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.False(t, called)
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	recv proto.Message
+	sent []any
+}
+
+func (s *testServerStream) RecvMsg(m any) error {
+	proto.Merge(m.(proto.Message), s.recv)
+	return nil
+}
+
+func (s *testServerStream) SendMsg(m any) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	// given
+	c := protocrypt.New(cipher.NewAES("test-secret", cipher.WithRandomNonce()))
+	interceptor := StreamServerInterceptor(c)
+
+	recv := newTestMessage(t, "request@example.com")
+	assert.NoError(t, c.Encrypt(recv))
+	stream := &testServerStream{recv: recv}
+
+	shared := newTestMessage(t, "response@example.com")
+	var received string
+	handler := func(srv any, stream grpc.ServerStream) error {
+		m := dynamicpb.NewMessage(recv.Descriptor())
+		if err := stream.RecvMsg(m); err != nil {
+			return err
+		}
+		received = email(m)
+		return stream.SendMsg(shared)
+	}
+
+	// when
+	err := interceptor(nil, stream, &grpc.StreamServerInfo{}, handler)
+	assert.NoError(t, err)
+
+	decrypted := proto.Clone(stream.sent[0].(proto.Message))
+	assert.NoError(t, c.Decrypt(decrypted))
+
+	// then
+	assert.Equal(t, "request@example.com", received)
+	assert.NotEqual(t, "response@example.com", email(stream.sent[0]))
+	assert.Equal(t, "response@example.com", email(decrypted))
+	assert.Equal(t, "response@example.com", email(shared))
+}
@@ -28,6 +28,22 @@ func Test() error {
 	)
 }
 
+// Generate protobuf go code
+func Proto() error {
+	err := sh.RunV("protoc", "-I", "proto",
+		"--go_out=proto", "--go_opt=paths=source_relative",
+		"proto/keecon/options.proto",
+	)
+	if err != nil {
+		return err
+	}
+
+	return sh.RunV("protoc", "-I", "proto", "-I", "crypto/protocrypt/internal/testpb",
+		"--go_out=crypto/protocrypt/internal/testpb", "--go_opt=paths=source_relative",
+		"test.proto",
+	)
+}
+
 // Show current version
 func Version() error {
 	cv, err := semver.LatestTag(".")
@@ -0,0 +1,91 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: keecon/options.proto
+
+package keecon
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var file_keecon_options_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50001,
+		Name:          "keecon.sensitive",
+		Tag:           "varint,50001,opt,name=sensitive",
+		Filename:      "keecon/options.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// sensitive marks the field is encrypted at rest and in logs.
+	// It applies to string and bytes fields, singular or repeated.
+	//
+	// The number is in the 50000-99999 range of FieldOptions, which is reserved
+	// for use within a single organization. It is reserved for this option in
+	// all KEECON protos, do not declare other FieldOptions extensions with it,
+	// and do not use this file outside KEECON without a number from the global
+	// extension registry.
+	//
+	// optional bool sensitive = 50001;
+	E_Sensitive = &file_keecon_options_proto_extTypes[0]
+)
+
+var File_keecon_options_proto protoreflect.FileDescriptor
+
+const file_keecon_options_proto_rawDesc = "" +
+	"\n" +
+	"\x14keecon/options.proto\x12\x06keecon\x1a google/protobuf/descriptor.proto:=\n" +
+	"\tsensitive\x12\x1d.google.protobuf.FieldOptions\x18ц\x03 \x01(\bR\tsensitiveB'Z%github.com/keecon/pkg-go/proto/keeconb\x06proto3"
+
+var file_keecon_options_proto_goTypes = []any{
+	(*descriptorpb.FieldOptions)(nil), // 0: google.protobuf.FieldOptions
+}
+var file_keecon_options_proto_depIdxs = []int32{
+	0, // 0: keecon.sensitive:extendee -> google.protobuf.FieldOptions
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_keecon_options_proto_init() }
+func file_keecon_options_proto_init() {
+	if File_keecon_options_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_keecon_options_proto_rawDesc), len(file_keecon_options_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_keecon_options_proto_goTypes,
+		DependencyIndexes: file_keecon_options_proto_depIdxs,
+		ExtensionInfos:    file_keecon_options_proto_extTypes,
+	}.Build()
+	File_keecon_options_proto = out.File
+	file_keecon_options_proto_goTypes = nil
+	file_keecon_options_proto_depIdxs = nil
+}